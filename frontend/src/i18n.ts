@@ -4,6 +4,7 @@ import LanguageDetector from "i18next-browser-languagedetector";
 import en from "@/assets/locales/en_US.json";
 import zh from "@/assets/locales/zh_CN.json";
 import ru from "@/assets/locales/ru_RU.json";
+import { SetLauncherLanguage } from "bindings/github.com/liteldev/LeviLauncher/minecraft";
 
 export const resources = {
   en_US: { translation: en },
@@ -46,4 +47,23 @@ i18n
     },
   });
 
+const toLauncherLanguage = (lng: string) => {
+  const lower = String(lng || "").toLowerCase();
+  if (lower === "en" || lower === "en-us" || lower === "en_us") return "en_US";
+  if (lower === "zh" || lower === "zh-cn" || lower === "zh_cn") return "zh_CN";
+  if (lower === "ru" || lower === "ru-ru" || lower === "ru_ru") return "ru_RU";
+  return lng;
+};
+
+const syncLauncherLanguage = (lng: string) => {
+  const code = toLauncherLanguage(lng);
+  if (!code) return;
+  try {
+    Promise.resolve(SetLauncherLanguage(code)).catch(() => {});
+  } catch {}
+};
+
+syncLauncherLanguage(i18n.language);
+i18n.on("languageChanged", syncLauncherLanguage);
+
 export default i18n;
@@ -49,6 +49,7 @@ import {
 } from "bindings/github.com/liteldev/LeviLauncher/minecraft";
 import * as types from "bindings/github.com/liteldev/LeviLauncher/internal/types/models";
 import { readCurrentVersionName } from "@/utils/currentVersion";
+import { useContentWatcher } from "@/utils/contentWatcher";
 import * as minecraft from "bindings/github.com/liteldev/LeviLauncher/minecraft";
 import { renderMcText } from "@/utils/mcformat";
 import { toast } from "react-hot-toast";
@@ -352,6 +353,9 @@ export default function BehaviorPacksPage() {
   React.useEffect(() => {
     refreshAll();
   }, []);
+  useContentWatcher(currentVersionName, ["behavior_pack"], () => {
+    refreshAll(true);
+  });
 
   React.useEffect(() => {
     try {
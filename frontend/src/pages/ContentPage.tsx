@@ -78,6 +78,10 @@ export default function ContentPage() {
     null,
   );
   const dupNameRef = React.useRef<string>("");
+  const downgradeResolveRef = React.useRef<((ok: boolean) => void) | null>(
+    null,
+  );
+  const downgradeNameRef = React.useRef<string>("");
   const {
     isOpen: errOpen,
     onOpen: errOnOpen,
@@ -88,6 +92,11 @@ export default function ContentPage() {
     onOpen: dupOnOpen,
     onOpenChange: dupOnOpenChange,
   } = useDisclosure();
+  const {
+    isOpen: downgradeOpen,
+    onOpen: downgradeOnOpen,
+    onOpenChange: downgradeOnOpenChange,
+  } = useDisclosure();
   const {
     isOpen: playerSelectOpen,
     onOpen: playerSelectOnOpen,
@@ -326,6 +335,15 @@ export default function ContentPage() {
     void doImportFromPaths(result);
   }, [location?.state?.fileManagerResult]);
 
+  const confirmDowngrade = async (fileName: string): Promise<boolean> => {
+    downgradeNameRef.current = fileName;
+    await new Promise<void>((r) => setTimeout(r, 0));
+    downgradeOnOpen();
+    return await new Promise<boolean>((resolve) => {
+      downgradeResolveRef.current = resolve;
+    });
+  };
+
   const doImportFromPaths = async (paths: string[]) => {
     try {
       if (!paths?.length) return;
@@ -518,12 +536,13 @@ export default function ContentPage() {
             started = true;
           }
           setCurrentFile(base);
-          let err = await (minecraft as any)?.ImportMcworldPath?.(
-            name,
-            playerToUse,
-            p,
-            false,
-          );
+          let importWorld = (minecraft as any)?.ImportMcworldPath;
+          let err = await importWorld?.(name, playerToUse, p, false);
+          if (String(err) === "ERR_WORLD_DOWNGRADE_RISK") {
+            if (!(await confirmDowngrade(base))) continue;
+            importWorld = (minecraft as any)?.ImportMcworldPathForce;
+            err = await importWorld?.(name, playerToUse, p, false);
+          }
           if (err) {
             if (
               String(err) === "ERR_DUPLICATE_FOLDER" ||
@@ -536,12 +555,7 @@ export default function ContentPage() {
                 dupResolveRef.current = resolve;
               });
               if (ok) {
-                err = await (minecraft as any)?.ImportMcworldPath?.(
-                  name,
-                  playerToUse,
-                  p,
-                  true,
-                );
+                err = await importWorld?.(name, playerToUse, p, true);
                 if (!err) {
                   succFiles.push(base);
                   continue;
@@ -650,6 +664,7 @@ export default function ContentPage() {
         setCurrentFile(f.name);
 
         let err = "";
+        let forceWorld = false;
         if (lower.endsWith(".mcpack")) {
           err = await postImportMcpack(currentVersionName, f, false);
         } else if (lower.endsWith(".mcaddon")) {
@@ -667,9 +682,21 @@ export default function ContentPage() {
               err = await (minecraft as any)?.ImportMcworld?.(
                 currentVersionName,
                 playerToUse,
+                f.name,
                 bytes,
                 false,
               );
+              if (String(err) === "ERR_WORLD_DOWNGRADE_RISK") {
+                if (!(await confirmDowngrade(f.name))) continue;
+                forceWorld = true;
+                err = await (minecraft as any)?.ImportMcworldForce?.(
+                  currentVersionName,
+                  playerToUse,
+                  f.name,
+                  bytes,
+                  false,
+                );
+              }
             } else {
               // Fallback: write temp file and use ImportMcworldPath
               // This requires exposing WriteTempFile which we don't know if we have.
@@ -700,10 +727,14 @@ export default function ContentPage() {
               } else if (lower.endsWith(".mcworld")) {
                 const buf = await f.arrayBuffer();
                 const bytes = Array.from(new Uint8Array(buf));
-                if (typeof (minecraft as any)?.ImportMcworld === "function") {
-                  err = await (minecraft as any)?.ImportMcworld?.(
+                const importWorld = forceWorld
+                  ? (minecraft as any)?.ImportMcworldForce
+                  : (minecraft as any)?.ImportMcworld;
+                if (typeof importWorld === "function") {
+                  err = await importWorld(
                     currentVersionName,
                     playerToUse,
+                    f.name,
                     bytes,
                     true,
                   );
@@ -1349,6 +1380,65 @@ export default function ContentPage() {
           )}
         </ModalContent>
       </BaseModal>
+      <BaseModal
+        size="md"
+        isOpen={downgradeOpen}
+        onOpenChange={downgradeOnOpenChange}
+        hideCloseButton
+      >
+        <ModalContent>
+          {(onClose) => (
+            <>
+              <BaseModalHeader className="text-warning-600">
+                {t("mods.downgrade_modal_title", {
+                  defaultValue: "存档版本较新",
+                })}
+              </BaseModalHeader>
+              <BaseModalBody>
+                <div className="text-sm text-default-700">
+                  {t("mods.downgrade_modal_body", {
+                    defaultValue:
+                      "该存档曾在比当前版本更新的游戏中打开，导入后用当前版本进入可能导致存档损坏。是否继续导入？",
+                  })}
+                </div>
+                {downgradeNameRef.current ? (
+                  <div className="mt-1 rounded-md bg-default-100/60 border border-default-200 px-3 py-2 text-default-800 text-sm wrap-break-word whitespace-pre-wrap">
+                    {downgradeNameRef.current}
+                  </div>
+                ) : null}
+              </BaseModalBody>
+              <BaseModalFooter>
+                <Button
+                  variant="light"
+                  onPress={() => {
+                    try {
+                      downgradeResolveRef.current &&
+                        downgradeResolveRef.current(false);
+                    } finally {
+                      onClose();
+                    }
+                  }}
+                >
+                  {t("common.cancel", { defaultValue: "取消" })}
+                </Button>
+                <Button
+                  color="warning"
+                  onPress={() => {
+                    try {
+                      downgradeResolveRef.current &&
+                        downgradeResolveRef.current(true);
+                    } finally {
+                      onClose();
+                    }
+                  }}
+                >
+                  {t("common.confirm", { defaultValue: "确定" })}
+                </Button>
+              </BaseModalFooter>
+            </>
+          )}
+        </ModalContent>
+      </BaseModal>
       <BaseModal
         size="md"
         isOpen={playerSelectOpen}
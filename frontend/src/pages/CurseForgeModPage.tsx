@@ -12,6 +12,7 @@ import {
   ImportMcaddonPath,
   ImportMcaddonPathWithPlayer,
   ImportMcworldPath,
+  ImportMcworldPathForce,
   IsMcpackSkinPackPath,
   StartFileDownload,
   CancelFileDownload,
@@ -161,6 +162,8 @@ const CurseForgeModPage: React.FC = () => {
   const [dupOpen, setDupOpen] = useState(false);
   const [dupName, setDupName] = useState<string>("");
   const dupResolveRef = useRef<((overwrite: boolean) => void) | null>(null);
+  const [downgradeOpen, setDowngradeOpen] = useState(false);
+  const downgradeResolveRef = useRef<((ok: boolean) => void) | null>(null);
   const isCancelling = useRef(false);
   const cleanupRef = useRef<() => void>(() => {});
 
@@ -343,16 +346,15 @@ const CurseForgeModPage: React.FC = () => {
 
     try {
       const { name, path, type } = installFile;
+      let forceWorld = false;
       const runImport = async (overwrite: boolean): Promise<string> => {
         if (type === "mcworld") {
           if (!selectedPlayer) throw new Error("No player selected");
+          const importWorld = forceWorld
+            ? ImportMcworldPathForce
+            : ImportMcworldPath;
           return String(
-            await ImportMcworldPath(
-              selectedVersion,
-              selectedPlayer,
-              path,
-              overwrite,
-            ),
+            await importWorld(selectedVersion, selectedPlayer, path, overwrite),
           );
         }
         if (type === "mcaddon") {
@@ -392,6 +394,18 @@ const CurseForgeModPage: React.FC = () => {
       };
 
       let err = await runImport(false);
+      if (err === "ERR_WORLD_DOWNGRADE_RISK") {
+        const ok = await new Promise<boolean>((resolve) => {
+          downgradeResolveRef.current = resolve;
+          setDowngradeOpen(true);
+        });
+        if (!ok) {
+          setInstallModalOpen(false);
+          return;
+        }
+        forceWorld = true;
+        err = await runImport(false);
+      }
       if (err) {
         if (
           String(err) === "ERR_DUPLICATE_FOLDER" ||
@@ -1248,6 +1262,75 @@ const CurseForgeModPage: React.FC = () => {
           )}
         </ModalContent>
       </BaseModal>
+      <BaseModal
+        size="md"
+        isOpen={downgradeOpen}
+        onOpenChange={(open) => {
+          if (!open) {
+            setDowngradeOpen(false);
+          }
+        }}
+        hideCloseButton
+        backdrop="blur"
+        classNames={{
+          base: "bg-white/80! dark:bg-zinc-900/80! backdrop-blur-2xl border-white/40! dark:border-zinc-700/50! shadow-2xl rounded-4xl",
+        }}
+      >
+        <ModalContent>
+          {(onClose) => (
+            <>
+              <BaseModalHeader className="">
+                <span className="text-xl font-bold text-warning-600">
+                  {t("mods.downgrade_modal_title", {
+                    defaultValue: "存档版本较新",
+                  })}
+                </span>
+              </BaseModalHeader>
+              <BaseModalBody>
+                <div className="text-sm text-default-700">
+                  {t("mods.downgrade_modal_body", {
+                    defaultValue:
+                      "该存档曾在比当前版本更新的游戏中打开，导入后用当前版本进入可能导致存档损坏。是否继续导入？",
+                  })}
+                </div>
+                {installFile?.name ? (
+                  <div className="mt-1 rounded-md bg-default-100/60 border border-default-200 px-3 py-2 text-default-800 text-sm wrap-break-word whitespace-pre-wrap">
+                    {installFile.name}
+                  </div>
+                ) : null}
+              </BaseModalBody>
+              <BaseModalFooter>
+                <Button
+                  variant="light"
+                  onPress={() => {
+                    try {
+                      if (downgradeResolveRef.current)
+                        downgradeResolveRef.current(false);
+                    } finally {
+                      onClose();
+                    }
+                  }}
+                >
+                  {t("common.cancel", { defaultValue: "取消" })}
+                </Button>
+                <Button
+                  color="warning"
+                  onPress={() => {
+                    try {
+                      if (downgradeResolveRef.current)
+                        downgradeResolveRef.current(true);
+                    } finally {
+                      onClose();
+                    }
+                  }}
+                >
+                  {t("common.confirm", { defaultValue: "确定" })}
+                </Button>
+              </BaseModalFooter>
+            </>
+          )}
+        </ModalContent>
+      </BaseModal>
     </div>
   );
 };
@@ -73,6 +73,11 @@ export const LauncherPage = (args: any) => {
   const hasBackend = minecraft !== undefined;
   const navigate = useNavigate();
   const [launchErrorCode, setLaunchErrorCode] = React.useState<string>("");
+  const [downgradeWorlds, setDowngradeWorlds] = React.useState<any[]>([]);
+  const [scriptIssues, setScriptIssues] = React.useState<any[]>([]);
+  const [backingUpWorlds, setBackingUpWorlds] = React.useState(false);
+  const [backupFailures, setBackupFailures] = React.useState<any[]>([]);
+  const risksAckedRef = useRef(false);
   const [contentCounts, setContentCounts] = React.useState<{
     worlds: number;
     resourcePacks: number;
@@ -301,60 +306,134 @@ export const LauncherPage = (args: any) => {
     } catch {}
   }, [versionMenuItems, ensureLogo]);
 
+  const runLaunch = React.useCallback(
+    (launch: () => Promise<string>) => {
+      launch()
+        .then((err: string) => {
+          const s = String(err || "");
+          if (
+            s === "ERR_WORLD_DOWNGRADE_RISK" ||
+            s === "ERR_SCRIPT_MODULE_RISK"
+          ) {
+            setModalState(18);
+            setOverlayActive(true);
+            onOpen();
+          } else if (s) {
+            setLaunchErrorCode(s);
+            setModalState(1);
+            setOverlayActive(true);
+            onOpen();
+          }
+        })
+        .catch(() => {
+          setLaunchErrorCode("ERR_LAUNCH_GAME");
+          setModalState(1);
+          setOverlayActive(true);
+          onOpen();
+        });
+    },
+    [onOpen],
+  );
+
   const doLaunch = React.useCallback(() => {
     const name = currentVersion;
     if (name) {
       saveCurrentVersionName(name);
+      risksAckedRef.current = false;
+      setDowngradeWorlds([]);
+      setScriptIssues([]);
+      setBackupFailures([]);
       const launch = minecraft?.LaunchVersionByName;
       if (typeof launch === "function") {
-        launch(name)
-          .then((err: string) => {
-            const s = String(err || "");
-            if (s) {
-              setLaunchErrorCode(s);
-              setModalState(1);
-              setOverlayActive(true);
-              onOpen();
-            }
-          })
-          .catch(() => {
-            setLaunchErrorCode("ERR_LAUNCH_GAME");
-            setModalState(1);
-            setOverlayActive(true);
-            onOpen();
-          });
+        runLaunch(() => launch(name));
       }
     } else {
       navigate("/versions");
     }
-  }, [currentVersion, navigate]);
+  }, [currentVersion, navigate, runLaunch]);
 
   const doForceLaunch = React.useCallback(() => {
     const name = currentVersion;
     if (name) {
       saveCurrentVersionName(name);
+      if (risksAckedRef.current) {
+        runLaunch(() => minecraft.LaunchVersionByNameIgnoreRisks(name, true));
+        return;
+      }
       const launchForce = minecraft?.LaunchVersionByNameForce;
       if (typeof launchForce === "function") {
-        launchForce(name)
-          .then((err: string) => {
-            const s = String(err || "");
-            if (s) {
-              setLaunchErrorCode(s);
-              setModalState(1);
-              setOverlayActive(true);
-              onOpen();
-            }
-          })
-          .catch(() => {
-            setLaunchErrorCode("ERR_LAUNCH_GAME");
-            setModalState(1);
-            setOverlayActive(true);
-            onOpen();
-          });
+        runLaunch(() => launchForce(name));
       }
     }
+  }, [currentVersion, runLaunch]);
+
+  const backupRiskWorlds = React.useCallback(async (): Promise<boolean> => {
+    const name = currentVersion;
+    if (!name) return false;
+    setBackingUpWorlds(true);
+    setBackupFailures([]);
+    try {
+      const res = await minecraft.BackupDowngradeRiskWorlds(name);
+      const failed = (res || []).filter((r: any) => r?.error);
+      setBackupFailures(failed);
+      return failed.length === 0;
+    } catch {
+      setBackupFailures([{ error: "ERR_BACKUP_WORLD" }]);
+      return false;
+    } finally {
+      setBackingUpWorlds(false);
+    }
   }, [currentVersion]);
 
+  const showPendingLaunch = React.useCallback(() => {
+    minecraft
+      .TakePendingLaunch()
+      .then((p: any) => {
+        const code = String(p?.code || "");
+        const name = String(p?.versionName || "");
+        if (!code || !name) return;
+        saveCurrentVersionName(name);
+        setCurrentVersion(name);
+        setDisplayName(name);
+        risksAckedRef.current = false;
+        setBackupFailures([]);
+        if (
+          code === "ERR_WORLD_DOWNGRADE_RISK" ||
+          code === "ERR_SCRIPT_MODULE_RISK"
+        ) {
+          setDowngradeWorlds(Array.isArray(p?.worlds) ? p.worlds : []);
+          setScriptIssues(Array.isArray(p?.issues) ? p.issues : []);
+          setModalState(18);
+        } else {
+          setLaunchErrorCode(code);
+          setModalState(1);
+        }
+        setOverlayActive(true);
+        onOpen();
+      })
+      .catch(() => {});
+  }, [onOpen]);
+
+  useEffect(() => {
+    showPendingLaunch();
+    const off = Events.On("launch.pending", () => showPendingLaunch());
+    return () => {
+      try {
+        off();
+      } catch {}
+    };
+  }, [showPendingLaunch]);
+
+  const doLaunchIgnoringRisks = React.useCallback(() => {
+    const name = currentVersion;
+    if (!name) return;
+    risksAckedRef.current = true;
+    setDowngradeWorlds([]);
+    setScriptIssues([]);
+    setBackupFailures([]);
+    runLaunch(() => minecraft.LaunchVersionByNameIgnoreRisks(name, false));
+  }, [currentVersion, runLaunch]);
+
   const doCreateShortcut = React.useCallback(() => {
     const name = currentVersion;
     if (name) {
@@ -674,6 +753,20 @@ export const LauncherPage = (args: any) => {
         return 0;
       });
     });
+    const unlistenDowngradeRisk = Events.On(
+      "world.downgrade_risk",
+      (data) => {
+        const payload: any = (data as any)?.data ?? data;
+        setDowngradeWorlds(Array.isArray(payload) ? payload : []);
+      },
+    );
+    const unlistenScriptRisk = Events.On(
+      "pack.script_module_risk",
+      (data) => {
+        const payload: any = (data as any)?.data ?? data;
+        setScriptIssues(Array.isArray(payload) ? payload : []);
+      },
+    );
     const unlistenMcFailed = Events.On("mc.launch.failed", (data) => {
       setOverlayActive(false);
       const payload: any = (data as any)?.data ?? data;
@@ -694,6 +787,12 @@ export const LauncherPage = (args: any) => {
       try {
         unlistenMcFailed && (unlistenMcFailed as any)();
       } catch {}
+      try {
+        unlistenDowngradeRisk && (unlistenDowngradeRisk as any)();
+      } catch {}
+      try {
+        unlistenScriptRisk && (unlistenScriptRisk as any)();
+      } catch {}
     };
   }, []);
 
@@ -1403,6 +1502,149 @@ export const LauncherPage = (args: any) => {
         </BaseModalFooter>
       </>
     ),
+    18: (onClose) => (
+      <>
+        <BaseModalHeader>
+          <h2 className="text-2xl font-black tracking-tight text-warning-500">
+            {scriptIssues.length > 0 && downgradeWorlds.length === 0
+              ? t("launcherpage.script_risk.title", {
+                  defaultValue: "行为包脚本模块不兼容",
+                })
+              : t("launcherpage.downgrade_risk.title", {
+                  defaultValue: "存档可能被降级",
+                })}
+          </h2>
+        </BaseModalHeader>
+        <BaseModalBody>
+          {(downgradeWorlds.length > 0 || scriptIssues.length === 0) && (
+            <p className="text-default-600 font-medium">
+              {t("launcherpage.downgrade_risk.body", {
+                defaultValue:
+                  "以下存档曾在更高版本的游戏中打开，使用当前版本进入可能导致存档损坏。建议先备份。",
+              })}
+            </p>
+          )}
+          {downgradeWorlds.length > 0 && (
+            <div className="mt-2 flex flex-col gap-2 max-h-60 overflow-y-auto">
+              {downgradeWorlds.map((w: any) => (
+                <div
+                  key={String(w?.worldDir || "")}
+                  className="flex items-center justify-between gap-3 rounded-xl bg-default-100/60 border border-default-200 px-3 py-2 text-sm"
+                >
+                  <span className="truncate text-default-800">
+                    {String(w?.levelName || w?.worldDir || "")}
+                  </span>
+                  <span className="shrink-0 font-mono text-default-500">
+                    {String(w?.worldVersion || "?")} →{" "}
+                    {String(w?.targetVersion || "?")}
+                  </span>
+                </div>
+              ))}
+            </div>
+          )}
+          {backupFailures.length > 0 && (
+            <>
+              <p className="text-danger-500 font-medium">
+                {t("launcherpage.downgrade_risk.backup_failed", {
+                  defaultValue: "以下存档备份失败，未启动游戏。",
+                })}
+              </p>
+              <div className="mt-2 flex flex-col gap-2 max-h-40 overflow-y-auto">
+                {backupFailures.map((r: any, i: number) => (
+                  <div
+                    key={`${String(r?.worldDir || "")}|${i}`}
+                    className="flex items-center justify-between gap-3 rounded-xl bg-danger-50/60 border border-danger-200 px-3 py-2 text-sm"
+                  >
+                    <span className="truncate text-default-800">
+                      {String(r?.levelName || r?.worldDir || "")}
+                    </span>
+                    <span className="shrink-0 text-danger-500">
+                      {t(`errors.${String(r?.error || "")}`, {
+                        defaultValue: String(r?.error || ""),
+                      })}
+                    </span>
+                  </div>
+                ))}
+              </div>
+            </>
+          )}
+          {scriptIssues.length > 0 && (
+            <>
+              <p className="text-default-600 font-medium">
+                {t("launcherpage.script_risk.body", {
+                  defaultValue:
+                    "以下行为包依赖的脚本模块版本不被当前游戏版本支持，进入世界时可能无法加载。",
+                })}
+              </p>
+              <div className="mt-2 flex flex-col gap-2 max-h-60 overflow-y-auto">
+                {scriptIssues.map((it: any, i: number) => (
+                  <div
+                    key={`${String(it?.packPath || "")}|${String(it?.module || "")}|${i}`}
+                    className="flex items-center justify-between gap-3 rounded-xl bg-default-100/60 border border-default-200 px-3 py-2 text-sm"
+                  >
+                    <span className="truncate text-default-800">
+                      {String(it?.packName || it?.packPath || "")}
+                    </span>
+                    <span className="shrink-0 font-mono text-default-500">
+                      {String(it?.module || "")}@{String(it?.version || "")}
+                    </span>
+                  </div>
+                ))}
+              </div>
+            </>
+          )}
+        </BaseModalBody>
+        <BaseModalFooter>
+          <Button
+            variant="light"
+            radius="full"
+            isDisabled={backingUpWorlds}
+            onPress={(e) => {
+              onClose?.(e);
+              setOverlayActive(false);
+              setModalState(0);
+            }}
+          >
+            {t("common.cancel", { defaultValue: "取消" }) as unknown as string}
+          </Button>
+          <Button
+            color="warning"
+            variant="flat"
+            radius="full"
+            isDisabled={backingUpWorlds}
+            onPress={(e) => {
+              onClose?.(e);
+              setOverlayActive(false);
+              setModalState(0);
+              doLaunchIgnoringRisks();
+            }}
+          >
+            {t("launcherpage.downgrade_risk.launch_anyway", {
+              defaultValue: "直接启动",
+            })}
+          </Button>
+          {downgradeWorlds.length > 0 && (
+            <Button
+              color="primary"
+              radius="full"
+              isLoading={backingUpWorlds}
+              className="bg-emerald-600 hover:bg-emerald-500 text-white font-bold shadow-lg shadow-emerald-900/20"
+              onPress={async (e) => {
+                if (!(await backupRiskWorlds())) return;
+                onClose?.(e);
+                setOverlayActive(false);
+                setModalState(0);
+                doLaunchIgnoringRisks();
+              }}
+            >
+              {t("launcherpage.downgrade_risk.backup_and_launch", {
+                defaultValue: "备份并启动",
+              })}
+            </Button>
+          )}
+        </BaseModalFooter>
+      </>
+    ),
   };
 
   const ModalUi = (onClose: ((e: PressEvent) => void) | undefined) => {
@@ -36,6 +36,7 @@ import { FiUploadCloud, FiAlertTriangle } from "react-icons/fi";
 import { AnimatePresence, motion } from "framer-motion";
 import * as minecraft from "bindings/github.com/liteldev/LeviLauncher/minecraft";
 import { PageHeader } from "@/components/PageHeader";
+import { useContentWatcher } from "@/utils/contentWatcher";
 
 const readCurrentVersionName = (): string => {
   try {
@@ -225,6 +226,10 @@ export const ModsPage: React.FC = () => {
     void refreshEnabledStates(name);
   }, []);
 
+  useContentWatcher(currentVersionName, ["mod"], () => {
+    void refreshAll();
+  });
+
   useEffect(() => {
     const id = window.setInterval(() => {
       const name = readCurrentVersionName();
@@ -25,6 +25,7 @@ import { PageHeader, SectionHeader } from "@/components/PageHeader";
 import { useNavigate, useLocation } from "react-router-dom";
 import {
   GetLanguageNames,
+  SetLauncherLanguage,
   GetBaseRoot,
   SetBaseRoot,
   ResetBaseRoot,
@@ -372,6 +373,7 @@ export default function OnboardingPage() {
                             const next = arr[0];
                             if (typeof next === "string" && next.length > 0) {
                               setSelectedLang(next);
+                              SetLauncherLanguage(next).catch(() => {});
                               Promise.resolve(i18n.changeLanguage(next)).then(
                                 () => {
                                   try {
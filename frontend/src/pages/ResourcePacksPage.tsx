@@ -50,6 +50,7 @@ import {
 import * as types from "bindings/github.com/liteldev/LeviLauncher/internal/types/models";
 import * as packages from "bindings/github.com/liteldev/LeviLauncher/internal/packages/models";
 import { readCurrentVersionName } from "@/utils/currentVersion";
+import { useContentWatcher } from "@/utils/contentWatcher";
 import * as minecraft from "bindings/github.com/liteldev/LeviLauncher/minecraft";
 import { renderMcText } from "@/utils/mcformat";
 import { toast } from "react-hot-toast";
@@ -360,6 +361,9 @@ export default function ResourcePacksPage() {
   React.useEffect(() => {
     refreshAll();
   }, []);
+  useContentWatcher(currentVersionName, ["resource_pack"], () => {
+    refreshAll(true);
+  });
   React.useEffect(() => {
     try {
       localStorage.setItem(
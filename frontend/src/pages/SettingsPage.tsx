@@ -28,6 +28,7 @@ import {
   GetAppVersion,
   CheckUpdate,
   GetLanguageNames,
+  SetLauncherLanguage,
   GetBaseRoot,
   SetBaseRoot,
   GetInstallerDir,
@@ -536,6 +537,7 @@ export const SettingsPage: React.FC = () => {
                         const next = arr[0];
                         if (typeof next === "string" && next.length > 0) {
                           setSelectedLang(next);
+                          SetLauncherLanguage(next).catch(() => {});
                           Promise.resolve(i18n.changeLanguage(next)).then(
                             () => {
                               try {
@@ -52,6 +52,7 @@ import {
 } from "bindings/github.com/liteldev/LeviLauncher/minecraft";
 import * as types from "bindings/github.com/liteldev/LeviLauncher/internal/types/models";
 import { readCurrentVersionName } from "@/utils/currentVersion";
+import { useContentWatcher } from "@/utils/contentWatcher";
 import { listPlayers } from "@/utils/content";
 import * as minecraft from "bindings/github.com/liteldev/LeviLauncher/minecraft";
 import { renderMcText } from "@/utils/mcformat";
@@ -370,6 +371,9 @@ export default function SkinPacksPage() {
   React.useEffect(() => {
     refreshAll();
   }, [refreshAll]);
+  useContentWatcher(currentVersionName, ["skin_pack"], () => {
+    refreshAll(true);
+  });
 
   // Old loadSkinPacks removed
 
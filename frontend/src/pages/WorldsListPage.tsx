@@ -51,6 +51,7 @@ import {
   OpenPathDir,
 } from "bindings/github.com/liteldev/LeviLauncher/minecraft";
 import { readCurrentVersionName } from "@/utils/currentVersion";
+import { useContentWatcher } from "@/utils/contentWatcher";
 import {
   BaseModal,
   BaseModalHeader,
@@ -190,6 +191,9 @@ export default function WorldsListPage() {
   useEffect(() => {
     refreshAll();
   }, [selectedPlayer]);
+  useContentWatcher(currentVersionName || "", ["world"], () => {
+    refreshAll();
+  });
 
   const refreshAll = useCallback(() => {
     setLoading(true);
@@ -0,0 +1,89 @@
+import React from "react";
+import { Events } from "@wailsio/runtime";
+import * as minecraft from "bindings/github.com/liteldev/LeviLauncher/minecraft";
+
+export type ContentChangeEvent = {
+  versionName: string;
+  kind: string;
+  change: string;
+  name: string;
+  path: string;
+  player: string;
+};
+
+const WATCHER_RELEASE_DELAY = 1500;
+const watcherRefs = new Map<string, number>();
+const pendingStops = new Map<string, ReturnType<typeof setTimeout>>();
+
+const acquireWatcher = (versionName: string) => {
+  const key = versionName.toLowerCase();
+  const pending = pendingStops.get(key);
+  if (pending) {
+    clearTimeout(pending);
+    pendingStops.delete(key);
+  }
+  const count = watcherRefs.get(key) || 0;
+  watcherRefs.set(key, count + 1);
+  if (count > 0 || pending) return;
+  try {
+    (minecraft as any)
+      ?.StartContentWatcher?.(versionName)
+      ?.catch?.(() => {});
+  } catch {}
+};
+
+const releaseWatcher = (versionName: string) => {
+  const key = versionName.toLowerCase();
+  const count = (watcherRefs.get(key) || 0) - 1;
+  if (count > 0) {
+    watcherRefs.set(key, count);
+    return;
+  }
+  watcherRefs.delete(key);
+  pendingStops.set(
+    key,
+    setTimeout(() => {
+      pendingStops.delete(key);
+      try {
+        (minecraft as any)
+          ?.StopContentWatcher?.(versionName)
+          ?.catch?.(() => {});
+      } catch {}
+    }, WATCHER_RELEASE_DELAY),
+  );
+};
+
+export function useContentWatcher(
+  versionName: string,
+  kinds: string[],
+  onChange: (events: ContentChangeEvent[]) => void,
+): void {
+  const onChangeRef = React.useRef(onChange);
+  onChangeRef.current = onChange;
+  const kindsKey = kinds.join(",");
+
+  React.useEffect(() => {
+    if (!versionName) return;
+    acquireWatcher(versionName);
+    return () => releaseWatcher(versionName);
+  }, [versionName]);
+
+  React.useEffect(() => {
+    if (!versionName) return;
+    const wanted = new Set(kindsKey.split(","));
+    const off = Events.On("content.changed", (event: any) => {
+      const list: ContentChangeEvent[] = Array.isArray(event?.data)
+        ? event.data
+        : [];
+      const matched = list.filter(
+        (e) => e?.versionName === versionName && wanted.has(e?.kind),
+      );
+      if (matched.length > 0) onChangeRef.current(matched);
+    });
+    return () => {
+      try {
+        off();
+      } catch {}
+    };
+  }, [versionName, kindsKey]);
+}
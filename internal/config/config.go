@@ -13,6 +13,7 @@ type AppConfig struct {
 	WindowWidth       int    `json:"window_width"`
 	WindowHeight      int    `json:"window_height"`
 	DisableDiscordRPC bool   `json:"disable_discord_rpc"`
+	Language          string `json:"language"`
 }
 
 func localAppData() string {
@@ -96,3 +97,8 @@ func GetDiscordRPCDisabled() bool {
 	c, _ := Load()
 	return c.DisableDiscordRPC
 }
+
+func GetLanguage() string {
+	c, _ := Load()
+	return strings.TrimSpace(c.Language)
+}
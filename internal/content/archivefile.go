@@ -0,0 +1,95 @@
+package content
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/safezip"
+)
+
+func withZipEntryTempFile(f *zip.File, fn func(r io.ReaderAt, size int64) string) string {
+	rc, err := f.Open()
+	if err != nil {
+		return "ERR_OPEN_ZIP"
+	}
+	defer rc.Close()
+	tmp, err := os.CreateTemp("", "levilauncher-nested-*.zip")
+	if err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+	n, err := io.Copy(tmp, io.LimitReader(rc, int64(f.UncompressedSize64)+1))
+	if err != nil {
+		return "ERR_READ_ZIP_ENTRY"
+	}
+	if uint64(n) > f.UncompressedSize64 {
+		return safezip.ErrorCode(safezip.ErrTooLarge)
+	}
+	return fn(tmp, n)
+}
+
+func WithArchiveFile(path string, fn func(r io.ReaderAt, size int64) string) string {
+	if strings.TrimSpace(path) == "" {
+		return "ERR_OPEN_ZIP"
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "ERR_OPEN_ZIP"
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil || fi.IsDir() {
+		return "ERR_OPEN_ZIP"
+	}
+	return fn(f, fi.Size())
+}
+
+func ImportMcpackFileToDirs2(path string, resDir string, bpDir string, skinDir string, overwrite bool) string {
+	return WithArchiveFile(path, func(r io.ReaderAt, size int64) string {
+		return ImportMcpackReaderToDirs2(r, size, filepath.Base(path), resDir, bpDir, skinDir, overwrite)
+	})
+}
+
+func ImportMcaddonFileToDirs2(path string, resDir string, bpDir string, skinDir string, overwrite bool) string {
+	return WithArchiveFile(path, func(r io.ReaderAt, size int64) string {
+		return ImportMcaddonReaderToDirs2(r, size, resDir, bpDir, skinDir, overwrite)
+	})
+}
+
+func ImportPackFilesToDirs2(paths []string, resDir string, bpDir string, skinDir string, overwrite bool) string {
+	return runImportTxn(func(txn *importTxn) string {
+		for _, p := range paths {
+			code := WithArchiveFile(p, func(r io.ReaderAt, size int64) string {
+				if strings.EqualFold(filepath.Ext(p), ".mcaddon") {
+					return importMcaddonToDirs2(txn, r, size, resDir, bpDir, skinDir, overwrite)
+				}
+				return importMcpackToDirs2(txn, r, size, filepath.Base(p), resDir, bpDir, skinDir, overwrite)
+			})
+			if code != "" {
+				return code
+			}
+		}
+		return ""
+	})
+}
+
+func ImportMcworldFileToDir(path string, worldsDir string, overwrite bool) string {
+	return WithArchiveFile(path, func(r io.ReaderAt, size int64) string {
+		return ImportMcworldReaderToDir(r, size, filepath.Base(path), worldsDir, overwrite)
+	})
+}
+
+func IsMcpackSkinPackFile(path string) bool {
+	return WithArchiveFile(path, func(r io.ReaderAt, size int64) string {
+		if IsMcpackSkinPackReader(r, size) {
+			return ""
+		}
+		return "ERR_NOT_SKIN_PACK"
+	}) == ""
+}
@@ -1,11 +1,11 @@
 package content
 
 import (
-	"archive/zip"
 	"bytes"
 	crand "crypto/rand"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"math"
@@ -15,10 +15,14 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	json "github.com/goccy/go-json"
 
+	"github.com/liteldev/LeviLauncher/internal/lang"
 	"github.com/liteldev/LeviLauncher/internal/nbt"
+	"github.com/liteldev/LeviLauncher/internal/packages"
+	"github.com/liteldev/LeviLauncher/internal/safezip"
 	"github.com/liteldev/LeviLauncher/internal/types"
 	"github.com/liteldev/LeviLauncher/internal/utils"
 )
@@ -220,70 +224,7 @@ type bedrockManifest struct {
 }
 
 func readPackTexts(root string) map[string]string {
-	textsDir := filepath.Join(root, "texts")
-	if !utils.DirExists(textsDir) {
-		return nil
-	}
-	langFile := filepath.Join(textsDir, "en_US.lang")
-	if !utils.FileExists(langFile) {
-		lj := filepath.Join(textsDir, "languages.json")
-		if utils.FileExists(lj) {
-			if b, err := os.ReadFile(lj); err == nil {
-				var langs []string
-				_ = json.Unmarshal(utils.JsonCompatBytes(b), &langs)
-				if len(langs) > 0 {
-					cand := filepath.Join(textsDir, strings.TrimSpace(langs[0])+".lang")
-					if utils.FileExists(cand) {
-						langFile = cand
-					}
-				}
-			}
-		}
-	}
-	if !utils.FileExists(langFile) {
-		ents, err := os.ReadDir(textsDir)
-		if err == nil {
-			for _, e := range ents {
-				if e.IsDir() {
-					continue
-				}
-				name := strings.ToLower(strings.TrimSpace(e.Name()))
-				if strings.HasSuffix(name, ".lang") {
-					langFile = filepath.Join(textsDir, e.Name())
-					break
-				}
-			}
-		}
-	}
-	if !utils.FileExists(langFile) {
-		return nil
-	}
-	b, err := os.ReadFile(langFile)
-	if err != nil {
-		return nil
-	}
-	lines := strings.Split(string(b), "\n")
-	m := make(map[string]string, 16)
-	for _, ln := range lines {
-		l := strings.TrimSpace(strings.TrimRight(ln, "\r"))
-		if l == "" || strings.HasPrefix(l, "#") {
-			continue
-		}
-		if idx := strings.IndexRune(l, '#'); idx >= 0 {
-			l = strings.TrimSpace(l[:idx])
-		}
-		if l == "" {
-			continue
-		}
-		if eq := strings.IndexRune(l, '='); eq >= 0 {
-			k := strings.TrimSpace(l[:eq])
-			v := strings.TrimSpace(l[eq+1:])
-			if k != "" {
-				m[k] = v
-			}
-		}
-	}
-	return m
+	return lang.ReadPackTexts(root).Resolve(lang.Current())
 }
 
 func findManifestDir(dir string) string {
@@ -363,6 +304,12 @@ func findPackPathsByUuid(uuid string, dirs ...string) []string {
 	return paths
 }
 
+func newTimestampID(now time.Time) string {
+	var b [4]byte
+	_, _ = crand.Read(b[:])
+	return fmt.Sprintf("%d_%s", now.UnixNano(), hex.EncodeToString(b[:]))
+}
+
 func generateRandomPackName() string {
 	b := make([]byte, 8)
 	_, _ = crand.Read(b)
@@ -370,12 +317,22 @@ func generateRandomPackName() string {
 }
 
 func ImportMcpackToDirs(data []byte, archiveName string, resDir string, bpDir string, overwrite bool) string {
-	if len(data) == 0 || (strings.TrimSpace(resDir) == "" && strings.TrimSpace(bpDir) == "") {
+	return ImportMcpackReaderToDirs(bytes.NewReader(data), int64(len(data)), archiveName, resDir, bpDir, overwrite)
+}
+
+func ImportMcpackReaderToDirs(r io.ReaderAt, size int64, archiveName string, resDir string, bpDir string, overwrite bool) string {
+	return runImportTxn(func(txn *importTxn) string {
+		return importMcpackToDirs(txn, r, size, archiveName, resDir, bpDir, overwrite)
+	})
+}
+
+func importMcpackToDirs(txn *importTxn, r io.ReaderAt, size int64, archiveName string, resDir string, bpDir string, overwrite bool) string {
+	if size == 0 || (strings.TrimSpace(resDir) == "" && strings.TrimSpace(bpDir) == "") {
 		return "ERR_OPEN_ZIP"
 	}
-	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	zr, err := safezip.NewReader(r, size)
 	if err != nil {
-		return "ERR_OPEN_ZIP"
+		return safezip.ErrorCode(err)
 	}
 	manifestDir := ""
 	var manifest bedrockManifest
@@ -408,9 +365,7 @@ func ImportMcpackToDirs(data []byte, archiveName string, resDir string, bpDir st
 			return "ERR_DUPLICATE_UUID"
 		}
 		for _, p := range existing {
-			if err := utils.RemoveDir(p); err != nil {
-				return "ERR_WRITE_FILE"
-			}
+			txn.replace(p)
 		}
 	}
 
@@ -435,61 +390,16 @@ func ImportMcpackToDirs(data []byte, archiveName string, resDir string, bpDir st
 	if !hasRes && !hasData {
 		return "ERR_INVALID_PACKAGE"
 	}
-	for _, targetRoot := range targets {
-		if utils.DirExists(targetRoot) {
-			if overwrite {
-				if err := utils.RemoveDir(targetRoot); err != nil {
-					return "ERR_WRITE_FILE"
-				}
-			} else {
-				return "ERR_DUPLICATE_FOLDER"
-			}
+	for _, finalRoot := range targets {
+		if utils.DirExists(finalRoot) && !overwrite {
+			return "ERR_DUPLICATE_FOLDER"
 		}
-		for _, f := range zr.File {
-			nameInZip := normalizeZipEntryName(f.Name)
-			var relInDir string
-			if manifestDir != "" {
-				if nameInZip != manifestDir && !strings.HasPrefix(nameInZip, manifestDir+"/") {
-					continue
-				}
-				relInDir = strings.TrimPrefix(strings.TrimPrefix(nameInZip, manifestDir), "/")
-			} else {
-				relInDir = nameInZip
-			}
-			target := targetRoot
-			if relInDir != "" && relInDir != "/" {
-				target = filepath.Join(targetRoot, relInDir)
-			}
-			safeTarget, _ := filepath.Abs(target)
-			safeRoot, _ := filepath.Abs(targetRoot)
-			if !strings.HasPrefix(strings.ToLower(safeTarget), strings.ToLower(safeRoot+string(os.PathSeparator))) && strings.ToLower(safeTarget) != strings.ToLower(safeRoot) {
-				continue
-			}
-			if f.FileInfo().IsDir() || strings.HasSuffix(f.Name, "/") {
-				if err := os.MkdirAll(target, 0755); err != nil {
-					return "ERR_CREATE_TARGET_DIR"
-				}
-				continue
-			}
-			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
-				return "ERR_CREATE_TARGET_DIR"
-			}
-			rc, err := f.Open()
-			if err != nil {
-				return "ERR_READ_ZIP_ENTRY"
-			}
-			out, er := os.Create(target)
-			if er != nil {
-				rc.Close()
-				return "ERR_WRITE_FILE"
-			}
-			if _, er = io.Copy(out, rc); er != nil {
-				out.Close()
-				rc.Close()
-				return "ERR_WRITE_FILE"
-			}
-			out.Close()
-			rc.Close()
+		targetRoot, err := txn.stage(finalRoot, "manifest.json")
+		if err != nil {
+			return "ERR_CREATE_TARGET_DIR"
+		}
+		if err := safezip.ExtractDir(zr, manifestDir, targetRoot); err != nil {
+			return safezip.ErrorCode(err)
 		}
 	}
 	return ""
@@ -512,23 +422,26 @@ func stripKnownArchiveExt(name string) string {
 }
 
 func normalizeZipEntryName(name string) string {
-	n := strings.TrimSpace(name)
-	n = strings.TrimPrefix(n, "./")
-	n = strings.ReplaceAll(n, "\\", "/")
-	n = strings.TrimPrefix(n, "/")
-	for strings.Contains(n, "//") {
-		n = strings.ReplaceAll(n, "//", "/")
-	}
-	return n
+	return safezip.CleanName(name)
 }
 
 func ImportMcpackToDirs2(data []byte, archiveName string, resDir string, bpDir string, skinDir string, overwrite bool) string {
-	if len(data) == 0 || (strings.TrimSpace(resDir) == "" && strings.TrimSpace(bpDir) == "" && strings.TrimSpace(skinDir) == "") {
+	return ImportMcpackReaderToDirs2(bytes.NewReader(data), int64(len(data)), archiveName, resDir, bpDir, skinDir, overwrite)
+}
+
+func ImportMcpackReaderToDirs2(r io.ReaderAt, size int64, archiveName string, resDir string, bpDir string, skinDir string, overwrite bool) string {
+	return runImportTxn(func(txn *importTxn) string {
+		return importMcpackToDirs2(txn, r, size, archiveName, resDir, bpDir, skinDir, overwrite)
+	})
+}
+
+func importMcpackToDirs2(txn *importTxn, r io.ReaderAt, size int64, archiveName string, resDir string, bpDir string, skinDir string, overwrite bool) string {
+	if size == 0 || (strings.TrimSpace(resDir) == "" && strings.TrimSpace(bpDir) == "" && strings.TrimSpace(skinDir) == "") {
 		return "ERR_OPEN_ZIP"
 	}
-	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	zr, err := safezip.NewReader(r, size)
 	if err != nil {
-		return "ERR_OPEN_ZIP"
+		return safezip.ErrorCode(err)
 	}
 	manifestDir := ""
 	var manifest bedrockManifest
@@ -560,9 +473,7 @@ func ImportMcpackToDirs2(data []byte, archiveName string, resDir string, bpDir s
 			return "ERR_DUPLICATE_UUID"
 		}
 		for _, p := range existing {
-			if err := utils.RemoveDir(p); err != nil {
-				return "ERR_WRITE_FILE"
-			}
+			txn.replace(p)
 		}
 	}
 
@@ -596,73 +507,38 @@ func ImportMcpackToDirs2(data []byte, archiveName string, resDir string, bpDir s
 		}
 		return "ERR_INVALID_PACKAGE"
 	}
-	for _, targetRoot := range targets {
-		if utils.DirExists(targetRoot) {
-			if overwrite {
-				if err := utils.RemoveDir(targetRoot); err != nil {
-					return "ERR_WRITE_FILE"
-				}
-			} else {
-				return "ERR_DUPLICATE_FOLDER"
-			}
+	for _, finalRoot := range targets {
+		if utils.DirExists(finalRoot) && !overwrite {
+			return "ERR_DUPLICATE_FOLDER"
 		}
-		for _, f := range zr.File {
-			nameInZip := normalizeZipEntryName(f.Name)
-			var relInDir string
-			if manifestDir != "" {
-				if nameInZip != manifestDir && !strings.HasPrefix(nameInZip, manifestDir+"/") {
-					continue
-				}
-				relInDir = strings.TrimPrefix(strings.TrimPrefix(nameInZip, manifestDir), "/")
-			} else {
-				relInDir = nameInZip
-			}
-			target := targetRoot
-			if relInDir != "" && relInDir != "/" {
-				target = filepath.Join(targetRoot, relInDir)
-			}
-			safeTarget, _ := filepath.Abs(target)
-			safeRoot, _ := filepath.Abs(targetRoot)
-			if !strings.HasPrefix(strings.ToLower(safeTarget), strings.ToLower(safeRoot+string(os.PathSeparator))) && strings.ToLower(safeTarget) != strings.ToLower(safeRoot) {
-				continue
-			}
-			if f.FileInfo().IsDir() || strings.HasSuffix(f.Name, "/") {
-				if err := os.MkdirAll(target, 0755); err != nil {
-					return "ERR_CREATE_TARGET_DIR"
-				}
-				continue
-			}
-			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
-				return "ERR_CREATE_TARGET_DIR"
-			}
-			rc, err := f.Open()
-			if err != nil {
-				return "ERR_READ_ZIP_ENTRY"
-			}
-			out, er := os.Create(target)
-			if er != nil {
-				rc.Close()
-				return "ERR_WRITE_FILE"
-			}
-			if _, er = io.Copy(out, rc); er != nil {
-				out.Close()
-				rc.Close()
-				return "ERR_WRITE_FILE"
-			}
-			out.Close()
-			rc.Close()
+		targetRoot, err := txn.stage(finalRoot, "manifest.json")
+		if err != nil {
+			return "ERR_CREATE_TARGET_DIR"
+		}
+		if err := safezip.ExtractDir(zr, manifestDir, targetRoot); err != nil {
+			return safezip.ErrorCode(err)
 		}
 	}
 	return ""
 }
 
 func ImportMcaddonToDirs2(data []byte, resDir string, bpDir string, skinDir string, overwrite bool) string {
-	if len(data) == 0 {
+	return ImportMcaddonReaderToDirs2(bytes.NewReader(data), int64(len(data)), resDir, bpDir, skinDir, overwrite)
+}
+
+func ImportMcaddonReaderToDirs2(r io.ReaderAt, size int64, resDir string, bpDir string, skinDir string, overwrite bool) string {
+	return runImportTxn(func(txn *importTxn) string {
+		return importMcaddonToDirs2(txn, r, size, resDir, bpDir, skinDir, overwrite)
+	})
+}
+
+func importMcaddonToDirs2(txn *importTxn, r io.ReaderAt, size int64, resDir string, bpDir string, skinDir string, overwrite bool) string {
+	if size == 0 {
 		return "ERR_OPEN_ZIP"
 	}
-	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	zr, err := safezip.NewReader(r, size)
 	if err != nil {
-		return "ERR_OPEN_ZIP"
+		return safezip.ErrorCode(err)
 	}
 	imported := false
 	hadSkin := false
@@ -670,14 +546,10 @@ func ImportMcaddonToDirs2(data []byte, resDir string, bpDir string, skinDir stri
 		name := strings.TrimSpace(f.Name)
 		lower := strings.ToLower(name)
 		if strings.HasSuffix(lower, ".mcpack") {
-			rc, er := f.Open()
-			if er != nil {
-				return "ERR_OPEN_ZIP"
-			}
-			b, _ := io.ReadAll(rc)
-			_ = rc.Close()
 			base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
-			err2 := ImportMcpackToDirs2(b, base, resDir, bpDir, skinDir, overwrite)
+			err2 := withZipEntryTempFile(f, func(r io.ReaderAt, size int64) string {
+				return importMcpackToDirs2(txn, r, size, base, resDir, bpDir, skinDir, overwrite)
+			})
 			if err2 != "" {
 				return err2
 			}
@@ -718,7 +590,7 @@ func ImportMcaddonToDirs2(data []byte, resDir string, bpDir string, skinDir stri
 				return "ERR_DUPLICATE_UUID"
 			}
 			for _, ex := range existing {
-				_ = utils.RemoveDir(ex)
+				txn.replace(ex)
 			}
 		}
 
@@ -752,57 +624,16 @@ func ImportMcaddonToDirs2(data []byte, resDir string, bpDir string, skinDir stri
 			}
 			continue
 		}
-		for _, targetRoot := range targets {
-			if utils.DirExists(targetRoot) {
-				if overwrite {
-					if err := utils.RemoveDir(targetRoot); err != nil {
-						return "ERR_WRITE_FILE"
-					}
-				} else {
-					return "ERR_DUPLICATE_FOLDER"
-				}
+		for _, finalRoot := range targets {
+			if utils.DirExists(finalRoot) && !overwrite {
+				return "ERR_DUPLICATE_FOLDER"
 			}
-			for _, f := range zr.File {
-				nameInZip := normalizeZipEntryName(f.Name)
-				var relInDir string
-				if nameInZip != p.dir && !strings.HasPrefix(nameInZip, p.dir+"/") {
-					continue
-				}
-				relInDir = strings.TrimPrefix(strings.TrimPrefix(nameInZip, p.dir), "/")
-				target := targetRoot
-				if relInDir != "" && relInDir != "/" {
-					target = filepath.Join(targetRoot, relInDir)
-				}
-				safeTarget, _ := filepath.Abs(target)
-				safeRoot, _ := filepath.Abs(targetRoot)
-				if !strings.HasPrefix(strings.ToLower(safeTarget), strings.ToLower(safeRoot+string(os.PathSeparator))) && strings.ToLower(safeTarget) != strings.ToLower(safeRoot) {
-					continue
-				}
-				if f.FileInfo().IsDir() || strings.HasSuffix(f.Name, "/") {
-					if err := os.MkdirAll(target, 0755); err != nil {
-						return "ERR_CREATE_TARGET_DIR"
-					}
-					continue
-				}
-				if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
-					return "ERR_CREATE_TARGET_DIR"
-				}
-				rc, err := f.Open()
-				if err != nil {
-					return "ERR_READ_ZIP_ENTRY"
-				}
-				out, er := os.Create(target)
-				if er != nil {
-					rc.Close()
-					return "ERR_WRITE_FILE"
-				}
-				if _, er = io.Copy(out, rc); er != nil {
-					out.Close()
-					rc.Close()
-					return "ERR_WRITE_FILE"
-				}
-				out.Close()
-				rc.Close()
+			targetRoot, err := txn.stage(finalRoot, "manifest.json")
+			if err != nil {
+				return "ERR_CREATE_TARGET_DIR"
+			}
+			if err := safezip.ExtractDir(zr, p.dir, targetRoot); err != nil {
+				return safezip.ErrorCode(err)
 			}
 		}
 		imported = true
@@ -817,26 +648,32 @@ func ImportMcaddonToDirs2(data []byte, resDir string, bpDir string, skinDir stri
 }
 
 func ImportMcaddonToDirs(data []byte, resDir string, bpDir string, overwrite bool) string {
-	if len(data) == 0 {
+	return ImportMcaddonReaderToDirs(bytes.NewReader(data), int64(len(data)), resDir, bpDir, overwrite)
+}
+
+func ImportMcaddonReaderToDirs(r io.ReaderAt, size int64, resDir string, bpDir string, overwrite bool) string {
+	return runImportTxn(func(txn *importTxn) string {
+		return importMcaddonToDirs(txn, r, size, resDir, bpDir, overwrite)
+	})
+}
+
+func importMcaddonToDirs(txn *importTxn, r io.ReaderAt, size int64, resDir string, bpDir string, overwrite bool) string {
+	if size == 0 {
 		return "ERR_OPEN_ZIP"
 	}
-	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	zr, err := safezip.NewReader(r, size)
 	if err != nil {
-		return "ERR_OPEN_ZIP"
+		return safezip.ErrorCode(err)
 	}
 	imported := false
 	for _, f := range zr.File {
 		name := strings.TrimSpace(f.Name)
 		lower := strings.ToLower(name)
 		if strings.HasSuffix(lower, ".mcpack") {
-			rc, er := f.Open()
-			if er != nil {
-				return "ERR_OPEN_ZIP"
-			}
-			b, _ := io.ReadAll(rc)
-			_ = rc.Close()
 			base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
-			err2 := ImportMcpackToDirs(b, base, resDir, bpDir, overwrite)
+			err2 := withZipEntryTempFile(f, func(r io.ReaderAt, size int64) string {
+				return importMcpackToDirs(txn, r, size, base, resDir, bpDir, overwrite)
+			})
 			if err2 != "" {
 				return err2
 			}
@@ -891,57 +728,16 @@ func ImportMcaddonToDirs(data []byte, resDir string, bpDir string, overwrite boo
 		if !hasRes && !hasData {
 			continue
 		}
-		for _, targetRoot := range targets {
-			if utils.DirExists(targetRoot) {
-				if overwrite {
-					if err := utils.RemoveDir(targetRoot); err != nil {
-						return "ERR_WRITE_FILE"
-					}
-				} else {
-					return "ERR_DUPLICATE_FOLDER"
-				}
+		for _, finalRoot := range targets {
+			if utils.DirExists(finalRoot) && !overwrite {
+				return "ERR_DUPLICATE_FOLDER"
 			}
-			for _, f := range zr.File {
-				nameInZip := normalizeZipEntryName(f.Name)
-				var relInDir string
-				if nameInZip != p.dir && !strings.HasPrefix(nameInZip, p.dir+"/") {
-					continue
-				}
-				relInDir = strings.TrimPrefix(strings.TrimPrefix(nameInZip, p.dir), "/")
-				target := targetRoot
-				if relInDir != "" && relInDir != "/" {
-					target = filepath.Join(targetRoot, relInDir)
-				}
-				safeTarget, _ := filepath.Abs(target)
-				safeRoot, _ := filepath.Abs(targetRoot)
-				if !strings.HasPrefix(strings.ToLower(safeTarget), strings.ToLower(safeRoot+string(os.PathSeparator))) && strings.ToLower(safeTarget) != strings.ToLower(safeRoot) {
-					continue
-				}
-				if f.FileInfo().IsDir() || strings.HasSuffix(f.Name, "/") {
-					if err := os.MkdirAll(target, 0755); err != nil {
-						return "ERR_CREATE_TARGET_DIR"
-					}
-					continue
-				}
-				if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
-					return "ERR_CREATE_TARGET_DIR"
-				}
-				rc, err := f.Open()
-				if err != nil {
-					return "ERR_READ_ZIP_ENTRY"
-				}
-				out, er := os.Create(target)
-				if er != nil {
-					rc.Close()
-					return "ERR_WRITE_FILE"
-				}
-				if _, er = io.Copy(out, rc); er != nil {
-					out.Close()
-					rc.Close()
-					return "ERR_WRITE_FILE"
-				}
-				out.Close()
-				rc.Close()
+			targetRoot, err := txn.stage(finalRoot, "manifest.json")
+			if err != nil {
+				return "ERR_CREATE_TARGET_DIR"
+			}
+			if err := safezip.ExtractDir(zr, p.dir, targetRoot); err != nil {
+				return safezip.ErrorCode(err)
 			}
 		}
 		imported = true
@@ -953,12 +749,22 @@ func ImportMcaddonToDirs(data []byte, resDir string, bpDir string, overwrite boo
 }
 
 func ImportMcworldToDir(data []byte, archiveName string, worldsDir string, overwrite bool) string {
-	if len(data) == 0 || strings.TrimSpace(worldsDir) == "" {
+	return ImportMcworldReaderToDir(bytes.NewReader(data), int64(len(data)), archiveName, worldsDir, overwrite)
+}
+
+func ImportMcworldReaderToDir(r io.ReaderAt, size int64, archiveName string, worldsDir string, overwrite bool) string {
+	return runImportTxn(func(txn *importTxn) string {
+		return importMcworldToDir(txn, r, size, archiveName, worldsDir, overwrite)
+	})
+}
+
+func importMcworldToDir(txn *importTxn, r io.ReaderAt, size int64, archiveName string, worldsDir string, overwrite bool) string {
+	if size == 0 || strings.TrimSpace(worldsDir) == "" {
 		return "ERR_OPEN_ZIP"
 	}
-	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	zr, err := safezip.NewReader(r, size)
 	if err != nil {
-		return "ERR_OPEN_ZIP"
+		return safezip.ErrorCode(err)
 	}
 	levelDir := ""
 	for _, f := range zr.File {
@@ -987,52 +793,12 @@ func ImportMcworldToDir(data []byte, archiveName string, worldsDir string, overw
 	if strings.TrimSpace(randomDir) == "" {
 		randomDir = "world"
 	}
-	targetRoot := filepath.Join(worldsDir, randomDir)
-	for _, f := range zr.File {
-		nameInZip := normalizeZipEntryName(f.Name)
-		var relInDir string
-		if levelDir != "" {
-			if nameInZip != levelDir && !strings.HasPrefix(nameInZip, levelDir+"/") {
-				continue
-			}
-			relInDir = strings.TrimPrefix(strings.TrimPrefix(nameInZip, levelDir), "/")
-		} else {
-			relInDir = nameInZip
-		}
-		target := targetRoot
-		if relInDir != "" && relInDir != "/" {
-			target = filepath.Join(targetRoot, relInDir)
-		}
-		safeTarget, _ := filepath.Abs(target)
-		safeRoot, _ := filepath.Abs(targetRoot)
-		if !strings.HasPrefix(strings.ToLower(safeTarget), strings.ToLower(safeRoot+string(os.PathSeparator))) && strings.ToLower(safeTarget) != strings.ToLower(safeRoot) {
-			continue
-		}
-		if f.FileInfo().IsDir() || strings.HasSuffix(f.Name, "/") {
-			if err := os.MkdirAll(target, 0755); err != nil {
-				return "ERR_CREATE_TARGET_DIR"
-			}
-			continue
-		}
-		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
-			return "ERR_CREATE_TARGET_DIR"
-		}
-		rc, err := f.Open()
-		if err != nil {
-			return "ERR_READ_ZIP_ENTRY"
-		}
-		out, er := os.Create(target)
-		if er != nil {
-			rc.Close()
-			return "ERR_WRITE_FILE"
-		}
-		if _, er = io.Copy(out, rc); er != nil {
-			out.Close()
-			rc.Close()
-			return "ERR_WRITE_FILE"
-		}
-		out.Close()
-		rc.Close()
+	targetRoot, err := txn.stage(filepath.Join(worldsDir, randomDir), "level.dat")
+	if err != nil {
+		return "ERR_CREATE_TARGET_DIR"
+	}
+	if err := safezip.ExtractDir(zr, levelDir, targetRoot); err != nil {
+		return safezip.ErrorCode(err)
 	}
 	return ""
 }
@@ -1099,6 +865,8 @@ func ReadPackInfoFromDir(dir string) types.PackInfo {
 		}
 	}
 	info.Path = target
+	info.Protection = packages.DetectProtection(target)
+	info.ReadOnly = info.Protection != ""
 	return info
 }
 
@@ -1120,12 +888,16 @@ func PutLevelDat(worldDir string, levelDatData []byte) error {
 	}
 	p := filepath.Join(worldDir, "level.dat")
 	backup := filepath.Join(worldDir, "level.dat_leviold")
-	if !utils.FileExists(backup) {
-		if b, err := os.ReadFile(p); err == nil {
-			_ = os.WriteFile(backup, b, 0644)
+	prev, _ := os.ReadFile(p)
+	if !utils.FileExists(backup) && len(prev) > 0 {
+		_ = os.WriteFile(backup, prev, 0644)
+	}
+	if len(prev) > 0 {
+		if err := recordLevelDatEdit(worldDir, prev, levelDatData); err != nil {
+			return err
 		}
 	}
-	return os.WriteFile(p, levelDatData, 0644)
+	return utils.WriteFileAtomic(p, levelDatData, 0644)
 }
 
 func GetLevelDatNbtAndVersion(worldDir string) ([]byte, int32, error) {
@@ -1314,6 +1086,7 @@ func ReadLevelDatFields(worldDir string) ([]types.LevelDatField, int32, error) {
 				}
 			}
 		}
+		annotateLevelDatField(&f)
 		out = append(out, f)
 	}
 	return out, ver, nil
@@ -1346,6 +1119,9 @@ func WriteLevelDatFields(worldDir string, fields []types.LevelDatField, version
 	if v, ok := root["Data"].(map[string]any); ok {
 		data = v
 	}
+	if err := checkLevelDatWrite(nil, data, fields); err != nil {
+		return err
+	}
 	byName := map[string]types.LevelDatField{}
 	for _, f := range fields {
 		byName[f.Name] = f
@@ -1620,10 +1396,14 @@ func WriteLevelDatFields(worldDir string, fields []types.LevelDatField, version
 }
 
 func IsMcpackSkinPack(data []byte) bool {
-	if len(data) == 0 {
+	return IsMcpackSkinPackReader(bytes.NewReader(data), int64(len(data)))
+}
+
+func IsMcpackSkinPackReader(r io.ReaderAt, size int64) bool {
+	if size == 0 {
 		return false
 	}
-	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	zr, err := safezip.NewReader(r, size)
 	if err != nil {
 		return false
 	}
@@ -1775,6 +1555,7 @@ func ReadLevelDatFieldsAt(worldDir string, path []string) ([]types.LevelDatField
 				}
 			}
 		}
+		annotateLevelDatField(&f)
 		out = append(out, f)
 	}
 	return out, ver, nil
@@ -1817,6 +1598,9 @@ func WriteLevelDatFieldsAt(worldDir string, path []string, fields []types.LevelD
 			cur = nm
 		}
 	}
+	if err := checkLevelDatWrite(path, cur, fields); err != nil {
+		return err
+	}
 	byName := map[string]types.LevelDatField{}
 	for _, f := range fields {
 		byName[f.Name] = f
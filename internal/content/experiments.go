@@ -0,0 +1,348 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	ExperimentBetaAPIs          = "gametest"
+	ExperimentHolidayCreator    = "data_driven_items"
+	ExperimentUpcomingCreator   = "upcoming_creator_features"
+	ExperimentMolangFeatures    = "experimental_molang_features"
+	ExperimentCustomBiomes      = "data_driven_biomes"
+	ExperimentJigsawStructures  = "jigsaw_structures"
+	ExperimentCreatorCameras    = "experimental_creator_cameras"
+	ExperimentVillagerTrades    = "villager_trades_rebalance"
+	ExperimentDeferredRendering = "deferred_technical_preview"
+)
+
+const (
+	experimentsKey              = "experiments"
+	experimentsEverUsedKey      = "experiments_ever_used"
+	savedWithToggledExperiments = "saved_with_toggled_experiments"
+)
+
+var ErrUnknownExperiment = errors.New("unknown experiment")
+
+type experimentDef struct {
+	key         string
+	label       string
+	description string
+}
+
+var knownExperiments = []experimentDef{
+	{ExperimentBetaAPIs, "Beta APIs", "Enables beta versions of the Script API modules such as @minecraft/server."},
+	{ExperimentHolidayCreator, "Holiday Creator Features", "Enables legacy data-driven item and block features such as events and triggers."},
+	{ExperimentUpcomingCreator, "Upcoming Creator Features", "Enables creator features that are still in development."},
+	{ExperimentMolangFeatures, "Experimental Molang Features", "Enables Molang queries and functions that are still experimental."},
+	{ExperimentCustomBiomes, "Custom Biomes", "Enables data-driven biomes defined in behavior packs."},
+	{ExperimentJigsawStructures, "Data-Driven Jigsaw Structures", "Enables jigsaw structures and template pools defined in behavior packs."},
+	{ExperimentCreatorCameras, "Experimental Creator Camera Features", "Enables experimental camera presets and camera commands."},
+	{ExperimentVillagerTrades, "Villager Trade Rebalancing", "Enables the rebalanced villager trades."},
+	{ExperimentDeferredRendering, "Render Dragon Features for Creators", "Enables the deferred technical preview renderer."},
+}
+
+func lookupExperiment(key string) (experimentDef, bool) {
+	for _, e := range knownExperiments {
+		if e.key == key {
+			return e, true
+		}
+	}
+	return experimentDef{}, false
+}
+
+func isExperimentMetaKey(key string) bool {
+	return key == experimentsEverUsedKey || key == savedWithToggledExperiments
+}
+
+func experimentEnabled(v any) bool {
+	return toInt64(v) != 0
+}
+
+func levelDatDataRoot(root map[string]any) map[string]any {
+	if v, ok := root["Data"].(map[string]any); ok {
+		return v
+	}
+	return root
+}
+
+func ReadWorldExperiments(worldDir string) ([]types.WorldExperiment, error) {
+	root, _, err := DecodeLevelDat(worldDir)
+	if err != nil {
+		return nil, err
+	}
+	exp, _ := levelDatDataRoot(root)[experimentsKey].(map[string]any)
+	out := make([]types.WorldExperiment, 0, len(knownExperiments)+len(exp))
+	for _, e := range knownExperiments {
+		out = append(out, types.WorldExperiment{
+			Key:         e.key,
+			Label:       e.label,
+			Description: e.description,
+			Enabled:     exp != nil && experimentEnabled(exp[e.key]),
+			Known:       true,
+		})
+	}
+	var unknown []string
+	for k := range exp {
+		if isExperimentMetaKey(k) {
+			continue
+		}
+		if _, ok := lookupExperiment(k); !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		out = append(out, types.WorldExperiment{Key: k, Label: k, Enabled: experimentEnabled(exp[k])})
+	}
+	return out, nil
+}
+
+func SetWorldExperiments(worldDir string, toggles map[string]bool) error {
+	root, ver, err := DecodeLevelDat(worldDir)
+	if err != nil {
+		return err
+	}
+	data := levelDatDataRoot(root)
+	exp, _ := data[experimentsKey].(map[string]any)
+	if exp == nil {
+		exp = map[string]any{}
+	}
+	for k, on := range toggles {
+		if isExperimentMetaKey(k) {
+			return fmt.Errorf("%w: %q is managed automatically", ErrUnknownExperiment, k)
+		}
+		if _, ok := lookupExperiment(k); !ok {
+			if _, present := exp[k]; !present {
+				return fmt.Errorf("%w: %q", ErrUnknownExperiment, k)
+			}
+		}
+		if on {
+			exp[k] = uint8(1)
+		} else {
+			exp[k] = uint8(0)
+		}
+	}
+	anyOn := false
+	for k, v := range exp {
+		if !isExperimentMetaKey(k) && experimentEnabled(v) {
+			anyOn = true
+			break
+		}
+	}
+	if anyOn {
+		exp[experimentsEverUsedKey] = uint8(1)
+		exp[savedWithToggledExperiments] = uint8(1)
+	}
+	data[experimentsKey] = exp
+	return EncodeLevelDat(worldDir, ver, root)
+}
+
+type WorldPackRef struct {
+	PackID  string          `json:"pack_id"`
+	Subpack string          `json:"subpack,omitempty"`
+	Version []int           `json:"version"`
+	Raw     json.RawMessage `json:"-"`
+}
+
+func ReadWorldPackRefs(worldDir string, fileName string) ([]WorldPackRef, error) {
+	b, err := os.ReadFile(filepath.Join(worldDir, fileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []WorldPackRef{}, nil
+		}
+		return nil, err
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(utils.JsonCompatBytes(b), &raws); err != nil {
+		return nil, err
+	}
+	refs := make([]WorldPackRef, 0, len(raws))
+	for _, raw := range raws {
+		var r WorldPackRef
+		_ = json.Unmarshal(raw, &r)
+		r.Raw = raw
+		refs = append(refs, r)
+	}
+	return refs, nil
+}
+
+func worldPackRefEntry(r WorldPackRef) any {
+	if len(r.Raw) == 0 {
+		return r
+	}
+	var orig WorldPackRef
+	_ = json.Unmarshal(r.Raw, &orig)
+	if orig.PackID == r.PackID && orig.Subpack == r.Subpack && slices.Equal(orig.Version, r.Version) {
+		return r.Raw
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(r.Raw, &fields); err != nil || fields == nil {
+		return r
+	}
+	fields["pack_id"], _ = json.Marshal(r.PackID)
+	fields["version"], _ = json.Marshal(r.Version)
+	if r.Subpack != "" {
+		fields["subpack"], _ = json.Marshal(r.Subpack)
+	} else {
+		delete(fields, "subpack")
+	}
+	return fields
+}
+
+func WriteWorldPackRefs(worldDir string, fileName string, refs []WorldPackRef) error {
+	out := make([]any, 0, len(refs))
+	for _, r := range refs {
+		out = append(out, worldPackRefEntry(r))
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(filepath.Join(worldDir, fileName), b, 0644)
+}
+
+func IndexPacksByUuid(dirs ...string) map[string]string {
+	idx := map[string]string{}
+	for _, root := range dirs {
+		if strings.TrimSpace(root) == "" || !utils.DirExists(root) {
+			continue
+		}
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			p := findManifestDir(filepath.Join(root, e.Name()))
+			if p == "" {
+				continue
+			}
+			b, err := os.ReadFile(filepath.Join(p, "manifest.json"))
+			if err != nil {
+				continue
+			}
+			var mf bedrockManifest
+			if err := json.Unmarshal(utils.JsonCompatBytes(b), &mf); err != nil {
+				continue
+			}
+			id := strings.ToLower(strings.TrimSpace(mf.Header.Uuid))
+			if id == "" {
+				continue
+			}
+			if _, ok := idx[id]; !ok {
+				idx[id] = p
+			}
+		}
+	}
+	return idx
+}
+
+func DetectPackExperiments(packDir string) []string {
+	need := map[string]struct{}{}
+	if b, err := os.ReadFile(filepath.Join(packDir, "manifest.json")); err == nil {
+		var raw struct {
+			Dependencies []struct {
+				ModuleName string `json:"module_name"`
+				Version    any    `json:"version"`
+			} `json:"dependencies"`
+		}
+		if json.Unmarshal(utils.JsonCompatBytes(b), &raw) == nil {
+			for _, d := range raw.Dependencies {
+				if strings.TrimSpace(d.ModuleName) == "" {
+					continue
+				}
+				if v, ok := d.Version.(string); ok && strings.Contains(strings.ToLower(v), "-beta") {
+					need[ExperimentBetaAPIs] = struct{}{}
+				}
+			}
+		}
+	}
+	if hasJSONFiles(filepath.Join(packDir, "biomes")) {
+		need[ExperimentCustomBiomes] = struct{}{}
+	}
+	if hasJSONFiles(filepath.Join(packDir, "worldgen", "template_pools")) || hasJSONFiles(filepath.Join(packDir, "worldgen", "jigsaw_structures")) {
+		need[ExperimentJigsawStructures] = struct{}{}
+	}
+	for _, sub := range []string{"items", "blocks"} {
+		if usesHolidayCreatorFeatures(filepath.Join(packDir, sub)) {
+			need[ExperimentHolidayCreator] = struct{}{}
+			break
+		}
+	}
+	out := make([]string, 0, len(need))
+	for k := range need {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func hasJSONFiles(dir string) bool {
+	if !utils.DirExists(dir) {
+		return false
+	}
+	found := false
+	_ = filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ".json") {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+func usesHolidayCreatorFeatures(dir string) bool {
+	if !utils.DirExists(dir) {
+		return false
+	}
+	found := false
+	_ = filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if found {
+			return filepath.SkipAll
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(p), ".json") {
+			return nil
+		}
+		b, er := os.ReadFile(p)
+		if er != nil {
+			return nil
+		}
+		var doc map[string]any
+		if json.Unmarshal(utils.JsonCompatBytes(b), &doc) != nil {
+			return nil
+		}
+		for _, key := range []string{"minecraft:item", "minecraft:block"} {
+			def, _ := doc[key].(map[string]any)
+			if def == nil {
+				continue
+			}
+			if ev, ok := def["events"].(map[string]any); ok && len(ev) > 0 {
+				found = true
+				return filepath.SkipAll
+			}
+		}
+		return nil
+	})
+	return found
+}
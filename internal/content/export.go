@@ -0,0 +1,86 @@
+package content
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+func packExportName(packDir string) string {
+	name := ""
+	if b, err := os.ReadFile(filepath.Join(packDir, "manifest.json")); err == nil {
+		var mf bedrockManifest
+		if json.Unmarshal(utils.JsonCompatBytes(b), &mf) == nil {
+			name = mf.Header.Name
+			if texts := readPackTexts(packDir); texts != nil {
+				if v, ok := texts[name]; ok {
+					name = v
+				}
+			}
+		}
+	}
+	if strings.TrimSpace(name) == "" {
+		name = filepath.Base(packDir)
+	}
+	return utils.SanitizeFilename(name)
+}
+
+func uniqueExportName(base string, used map[string]struct{}) string {
+	name := base
+	for i := 2; ; i++ {
+		if _, ok := used[strings.ToLower(name)]; !ok {
+			used[strings.ToLower(name)] = struct{}{}
+			return name
+		}
+		name = base + "_" + strconv.Itoa(i)
+	}
+}
+
+func zipPacks(packDirs []string, nested bool, dest string) error {
+	return utils.WriteFileAtomicFunc(dest, 0644, func(w io.Writer) error {
+		zw := zip.NewWriter(w)
+		used := map[string]struct{}{}
+		for _, dir := range packDirs {
+			prefix := ""
+			if nested {
+				prefix = uniqueExportName(packExportName(dir), used)
+			}
+			if err := utils.AddDirToZipDeterministic(zw, dir, prefix); err != nil {
+				zw.Close()
+				return err
+			}
+		}
+		return zw.Close()
+	})
+}
+
+func ExportMcpack(packDir string, dest string) error {
+	return zipPacks([]string{packDir}, false, dest)
+}
+
+func ExportMcpacks(packDirs []string, destDir string) ([]string, error) {
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, err
+	}
+	used := map[string]struct{}{}
+	files := make([]string, 0, len(packDirs))
+	for _, dir := range packDirs {
+		dest := filepath.Join(destDir, uniqueExportName(packExportName(dir), used)+".mcpack")
+		if err := ExportMcpack(dir, dest); err != nil {
+			return files, err
+		}
+		files = append(files, dest)
+	}
+	return files, nil
+}
+
+func ExportMcaddon(packDirs []string, dest string) error {
+	return zipPacks(packDirs, true, dest)
+}
@@ -0,0 +1,58 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+type GlobalPackRef struct {
+	PackID  string          `json:"pack_id"`
+	Subpack string          `json:"subpack,omitempty"`
+	Version []int           `json:"version"`
+	Raw     json.RawMessage `json:"-"`
+}
+
+func ReadGlobalResourcePacks(file string) ([]GlobalPackRef, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []GlobalPackRef{}, nil
+		}
+		return nil, err
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(utils.JsonCompatBytes(b), &raws); err != nil {
+		return nil, err
+	}
+	refs := make([]GlobalPackRef, 0, len(raws))
+	for _, raw := range raws {
+		var r GlobalPackRef
+		_ = json.Unmarshal(raw, &r)
+		r.Raw = raw
+		refs = append(refs, r)
+	}
+	return refs, nil
+}
+
+func WriteGlobalResourcePacks(file string, refs []GlobalPackRef) error {
+	out := make([]any, 0, len(refs))
+	for _, r := range refs {
+		if len(r.Raw) > 0 {
+			out = append(out, r.Raw)
+		} else {
+			out = append(out, r)
+		}
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(file, b, 0644)
+}
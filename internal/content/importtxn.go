@@ -0,0 +1,315 @@
+package content
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	importsDirName       = "imports"
+	stagingDirName       = ".levilauncher_staging"
+	replacedDirName      = "replaced"
+	replacedManifestName = "replaced.json"
+	maxReplacedBytes     = 2 << 30
+)
+
+var ErrReplacedContentMissing = errors.New("replaced content not found")
+
+type stagedImport struct {
+	staging  string
+	final    string
+	required string
+}
+
+type importTxn struct {
+	id        string
+	staged    []stagedImport
+	displaced []string
+}
+
+var replacedPathHook func(path string)
+
+func SetReplacedPathHook(fn func(path string)) {
+	replacedPathHook = fn
+}
+
+func newImportTxn() *importTxn {
+	return &importTxn{id: newTimestampID(time.Now())}
+}
+
+func contentAreaOf(p string) string {
+	return filepath.Dir(filepath.Dir(p))
+}
+
+func stagingDir(final string, id string) string {
+	return filepath.Join(contentAreaOf(final), stagingDirName, id)
+}
+
+func replacedRoot() string {
+	return filepath.Join(utils.BaseRoot(), importsDirName, replacedDirName)
+}
+
+func areaKey(area string) string {
+	abs, err := filepath.Abs(area)
+	if err != nil {
+		abs = area
+	}
+	sum := sha1.Sum([]byte(strings.ToLower(filepath.Clean(abs))))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func replacedDir(area string, id string) string {
+	return filepath.Join(replacedRoot(), id, areaKey(area))
+}
+
+func movePath(from string, to string) error {
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+	tmp := to + ".levilauncher_move"
+	_ = os.RemoveAll(tmp)
+	if err := utils.CopyDir(from, tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, to); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	return os.RemoveAll(from)
+}
+
+func (t *importTxn) stage(final string, required string) (string, error) {
+	for _, s := range t.staged {
+		if strings.EqualFold(filepath.Clean(s.final), filepath.Clean(final)) {
+			return s.staging, nil
+		}
+	}
+	staging := filepath.Join(stagingDir(final, t.id), fmt.Sprintf("%d_%s", len(t.staged), filepath.Base(final)))
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		return "", err
+	}
+	t.staged = append(t.staged, stagedImport{staging: staging, final: final, required: required})
+	if utils.DirExists(final) {
+		t.replace(final)
+	}
+	return staging, nil
+}
+
+func (t *importTxn) replace(p string) {
+	for _, d := range t.displaced {
+		if strings.EqualFold(filepath.Clean(d), filepath.Clean(p)) {
+			return
+		}
+	}
+	t.displaced = append(t.displaced, p)
+}
+
+func (t *importTxn) validate() string {
+	for _, s := range t.staged {
+		if s.required != "" && !utils.FileExists(filepath.Join(s.staging, s.required)) {
+			return "ERR_INVALID_PACKAGE"
+		}
+	}
+	return ""
+}
+
+func (t *importTxn) cleanupStaging() {
+	seen := map[string]struct{}{}
+	for _, s := range t.staged {
+		dir := stagingDir(s.final, t.id)
+		if _, ok := seen[dir]; ok {
+			continue
+		}
+		seen[dir] = struct{}{}
+		_ = os.RemoveAll(dir)
+		_ = os.Remove(filepath.Dir(dir))
+	}
+}
+
+func (t *importTxn) rollback() {
+	t.cleanupStaging()
+}
+
+func (t *importTxn) commit() error {
+	type move struct{ from, to string }
+	var done []move
+	undo := func() {
+		for i := len(done) - 1; i >= 0; i-- {
+			_ = movePath(done[i].to, done[i].from)
+		}
+	}
+	backups := map[string][]types.ReplacedContentItem{}
+	for i, p := range t.displaced {
+		dst := filepath.Join(replacedDir(contentAreaOf(p), t.id), fmt.Sprintf("%d_%s", i, filepath.Base(p)))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			undo()
+			return err
+		}
+		if err := movePath(p, dst); err != nil {
+			undo()
+			return err
+		}
+		done = append(done, move{p, dst})
+		area := contentAreaOf(p)
+		backups[area] = append(backups[area], types.ReplacedContentItem{Original: p, Backup: dst})
+	}
+	for _, s := range t.staged {
+		if err := os.MkdirAll(filepath.Dir(s.final), 0755); err != nil {
+			undo()
+			return err
+		}
+		if err := movePath(s.staging, s.final); err != nil {
+			undo()
+			return err
+		}
+		done = append(done, move{s.staging, s.final})
+	}
+	t.cleanupStaging()
+	if replacedPathHook != nil {
+		for _, p := range t.displaced {
+			replacedPathHook(p)
+		}
+	}
+	imported := make([]string, 0, len(t.staged))
+	for _, s := range t.staged {
+		imported = append(imported, s.final)
+	}
+	for area, items := range backups {
+		rec := types.ReplacedContent{ID: t.id, Ts: time.Now().Unix(), Area: area, Replaced: items, Imported: imported}
+		if b, err := json.MarshalIndent(rec, "", "  "); err == nil {
+			_ = utils.WriteFileAtomic(filepath.Join(replacedDir(area, t.id), replacedManifestName), b, 0644)
+		}
+	}
+	if len(backups) > 0 {
+		pruneReplacedContent()
+	}
+	return nil
+}
+
+func runImportTxn(fn func(txn *importTxn) string) string {
+	txn := newImportTxn()
+	if code := fn(txn); code != "" {
+		txn.rollback()
+		return code
+	}
+	if code := txn.validate(); code != "" {
+		txn.rollback()
+		return code
+	}
+	if err := txn.commit(); err != nil {
+		txn.rollback()
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
+
+func readReplacedRecords() []types.ReplacedContent {
+	out := []types.ReplacedContent{}
+	matches, _ := filepath.Glob(filepath.Join(replacedRoot(), "*", "*", replacedManifestName))
+	for _, m := range matches {
+		b, err := os.ReadFile(m)
+		if err != nil {
+			continue
+		}
+		var rec types.ReplacedContent
+		if json.Unmarshal(b, &rec) == nil && rec.ID != "" {
+			out = append(out, rec)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Ts > out[j].Ts })
+	return out
+}
+
+func ListReplacedContent(areas ...string) []types.ReplacedContent {
+	out := []types.ReplacedContent{}
+	keys := map[string]struct{}{}
+	for _, area := range areas {
+		if strings.TrimSpace(area) != "" {
+			keys[areaKey(area)] = struct{}{}
+		}
+	}
+	for _, rec := range readReplacedRecords() {
+		if _, ok := keys[areaKey(rec.Area)]; ok {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
+
+func RestoreReplacedContent(area string, id string) error {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return ErrReplacedContentMissing
+	}
+	dir := replacedDir(area, id)
+	b, err := os.ReadFile(filepath.Join(dir, replacedManifestName))
+	if err != nil {
+		return ErrReplacedContentMissing
+	}
+	var rec types.ReplacedContent
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return ErrReplacedContentMissing
+	}
+	for _, p := range rec.Imported {
+		if utils.IsPathWithin(area, p) {
+			if err := utils.RemoveDir(p); err != nil {
+				return err
+			}
+		}
+	}
+	for _, it := range rec.Replaced {
+		if !utils.IsPathWithin(dir, it.Backup) || !utils.DirExists(it.Backup) {
+			continue
+		}
+		if utils.DirExists(it.Original) {
+			if err := utils.RemoveDir(it.Original); err != nil {
+				return err
+			}
+		}
+		if err := movePath(it.Backup, it.Original); err != nil {
+			return err
+		}
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	_ = os.Remove(filepath.Dir(dir))
+	return nil
+}
+
+func dirSize(dir string) int64 {
+	var n int64
+	_ = filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			n += info.Size()
+		}
+		return nil
+	})
+	return n
+}
+
+func pruneReplacedContent() {
+	var total int64
+	for i, rec := range readReplacedRecords() {
+		dir := replacedDir(rec.Area, rec.ID)
+		total += dirSize(dir)
+		if i == 0 || total <= maxReplacedBytes {
+			continue
+		}
+		_ = os.RemoveAll(dir)
+		_ = os.Remove(filepath.Dir(dir))
+	}
+}
@@ -0,0 +1,223 @@
+package content
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/nbt"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const maxLevelDatHistory = 50
+
+var (
+	levelDatHistoryMu      sync.Mutex
+	ErrLevelDatEditMissing = errors.New("level.dat edit not found")
+)
+
+type levelDatJournal struct {
+	WorldDir string               `json:"worldDir"`
+	Edits    []types.LevelDatEdit `json:"edits"`
+}
+
+func levelDatHistoryDir(worldDir string) string {
+	abs, err := filepath.Abs(worldDir)
+	if err != nil {
+		abs = worldDir
+	}
+	sum := sha1.Sum([]byte(strings.ToLower(filepath.Clean(abs))))
+	return filepath.Join(utils.BaseRoot(), "history", "leveldat", hex.EncodeToString(sum[:]))
+}
+
+func readLevelDatJournal(dir string) levelDatJournal {
+	var j levelDatJournal
+	if b, err := os.ReadFile(filepath.Join(dir, "journal.json")); err == nil {
+		_ = json.Unmarshal(b, &j)
+	}
+	return j
+}
+
+func writeLevelDatJournal(dir string, j levelDatJournal) error {
+	b, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(filepath.Join(dir, "journal.json"), b, 0644)
+}
+
+func recordLevelDatEdit(worldDir string, prev []byte, next []byte) error {
+	changes := diffLevelDatBytes(prev, next)
+	if len(changes) == 0 && len(prev) == len(next) && string(prev) == string(next) {
+		return nil
+	}
+	levelDatHistoryMu.Lock()
+	defer levelDatHistoryMu.Unlock()
+	dir := levelDatHistoryDir(worldDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	j := readLevelDatJournal(dir)
+	j.WorldDir = worldDir
+	now := time.Now()
+	edit := types.LevelDatEdit{
+		ID:      newTimestampID(now),
+		Ts:      now.Unix(),
+		Summary: summarizeLevelDatChanges(changes),
+		Size:    int64(len(prev)),
+		Changes: changes,
+	}
+	if err := utils.WriteFileAtomic(filepath.Join(dir, edit.ID+".dat"), prev, 0644); err != nil {
+		return err
+	}
+	j.Edits = append(j.Edits, edit)
+	for len(j.Edits) > maxLevelDatHistory {
+		_ = os.Remove(filepath.Join(dir, j.Edits[0].ID+".dat"))
+		j.Edits = j.Edits[1:]
+	}
+	return writeLevelDatJournal(dir, j)
+}
+
+func summarizeLevelDatChanges(changes []types.LevelDatChange) string {
+	if len(changes) == 0 {
+		return "no field changes"
+	}
+	names := make([]string, 0, 3)
+	for i, c := range changes {
+		if i == 3 {
+			break
+		}
+		names = append(names, strings.Join(c.Path, "/"))
+	}
+	s := strings.Join(names, ", ")
+	if len(changes) > 3 {
+		s += fmt.Sprintf(" and %d more", len(changes)-3)
+	}
+	return s
+}
+
+func ListLevelDatHistory(worldDir string) []types.LevelDatEdit {
+	levelDatHistoryMu.Lock()
+	j := readLevelDatJournal(levelDatHistoryDir(worldDir))
+	levelDatHistoryMu.Unlock()
+	out := make([]types.LevelDatEdit, 0, len(j.Edits))
+	for i := len(j.Edits) - 1; i >= 0; i-- {
+		out = append(out, j.Edits[i])
+	}
+	return out
+}
+
+func readLevelDatEditBytes(worldDir string, id string) ([]byte, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return nil, ErrLevelDatEditMissing
+	}
+	levelDatHistoryMu.Lock()
+	defer levelDatHistoryMu.Unlock()
+	dir := levelDatHistoryDir(worldDir)
+	found := false
+	for _, e := range readLevelDatJournal(dir).Edits {
+		if e.ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, ErrLevelDatEditMissing
+	}
+	b, err := os.ReadFile(filepath.Join(dir, id+".dat"))
+	if err != nil {
+		return nil, ErrLevelDatEditMissing
+	}
+	return b, nil
+}
+
+func PreviewLevelDatRevert(worldDir string, id string) ([]types.LevelDatChange, error) {
+	prev, err := readLevelDatEditBytes(worldDir, id)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := GetLevelDat(worldDir)
+	if err != nil {
+		return nil, err
+	}
+	return diffLevelDatBytes(cur, prev), nil
+}
+
+func RevertLevelDatEdit(worldDir string, id string) error {
+	prev, err := readLevelDatEditBytes(worldDir, id)
+	if err != nil {
+		return err
+	}
+	return PutLevelDat(worldDir, prev)
+}
+
+func decodeLevelDatBytes(b []byte) map[string]any {
+	if len(b) < 8 {
+		return nil
+	}
+	var root map[string]any
+	if err := nbt.UnmarshalEncoding(b[8:], &root, nbt.LittleEndian); err != nil {
+		return nil
+	}
+	return root
+}
+
+func diffLevelDatBytes(prev []byte, next []byte) []types.LevelDatChange {
+	changes := []types.LevelDatChange{}
+	diffLevelDatMaps(nil, decodeLevelDatBytes(prev), decodeLevelDatBytes(next), &changes)
+	return changes
+}
+
+func diffLevelDatMaps(path []string, a map[string]any, b map[string]any, out *[]types.LevelDatChange) {
+	keys := make([]string, 0, len(a)+len(b))
+	seen := map[string]struct{}{}
+	for k := range a {
+		keys = append(keys, k)
+		seen[k] = struct{}{}
+	}
+	for k := range b {
+		if _, ok := seen[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p := append(append([]string{}, path...), k)
+		av, aok := a[k]
+		bv, bok := b[k]
+		am, aIsMap := av.(map[string]any)
+		bm, bIsMap := bv.(map[string]any)
+		switch {
+		case aok && bok && aIsMap && bIsMap:
+			diffLevelDatMaps(p, am, bm, out)
+		case aok && !bok:
+			*out = append(*out, types.LevelDatChange{Path: p, Kind: "removed", Old: levelDatDisplayValue(av)})
+		case !aok && bok:
+			*out = append(*out, types.LevelDatChange{Path: p, Kind: "added", New: levelDatDisplayValue(bv)})
+		default:
+			if reflect.DeepEqual(av, bv) {
+				continue
+			}
+			*out = append(*out, types.LevelDatChange{Path: p, Kind: "modified", Old: levelDatDisplayValue(av), New: levelDatDisplayValue(bv)})
+		}
+	}
+}
+
+func levelDatDisplayValue(v any) string {
+	if s, ok := levelDatCurrentValue(v); ok {
+		return s
+	}
+	return normalizeLevelDatJSON(v)
+}
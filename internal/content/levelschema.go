@@ -0,0 +1,422 @@
+package content
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/types"
+)
+
+const (
+	LevelDatKindBool   = "bool"
+	LevelDatKindInt    = "int"
+	LevelDatKindFloat  = "float"
+	LevelDatKindEnum   = "enum"
+	LevelDatKindString = "string"
+	LevelDatKindList   = "list"
+)
+
+const (
+	LevelDatCategoryGameRules   = "gamerules"
+	LevelDatCategoryWorld       = "world"
+	LevelDatCategorySpawn       = "spawn"
+	LevelDatCategoryCheats      = "cheats"
+	LevelDatCategoryMultiplayer = "multiplayer"
+	LevelDatCategoryEducation   = "education"
+	LevelDatCategoryWeather     = "weather"
+	LevelDatCategoryTemplate    = "template"
+	LevelDatCategoryAbilities   = "abilities"
+	LevelDatCategoryTechnical   = "technical"
+)
+
+type LevelDatValidationError struct {
+	Issues []types.LevelDatFieldIssue
+}
+
+func (e *LevelDatValidationError) Error() string {
+	if len(e.Issues) == 0 {
+		return "level.dat validation failed"
+	}
+	first := e.Issues[0]
+	return fmt.Sprintf("level.dat validation failed: %s: %s (%d issue(s))", strings.Join(append(append([]string{}, first.Path...), first.Name), "/"), first.Code, len(e.Issues))
+}
+
+func gameRuleBool(name, label string) types.LevelDatSchemaField {
+	return types.LevelDatSchemaField{Name: name, Tag: "byte", Kind: LevelDatKindBool, Category: LevelDatCategoryGameRules, Label: label}
+}
+
+func gameRuleInt(name, label string, min, max *float64) types.LevelDatSchemaField {
+	return types.LevelDatSchemaField{Name: name, Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategoryGameRules, Label: label, Min: min, Max: max}
+}
+
+func settingBool(category, name, label string) types.LevelDatSchemaField {
+	return types.LevelDatSchemaField{Name: name, Tag: "byte", Kind: LevelDatKindBool, Category: category, Label: label}
+}
+
+func settingEnum(category, name, label string, opts ...types.LevelDatEnumOption) types.LevelDatSchemaField {
+	return types.LevelDatSchemaField{Name: name, Tag: "int", Kind: LevelDatKindEnum, Category: category, Label: label, Enum: opts}
+}
+
+func readOnly(f types.LevelDatSchemaField) types.LevelDatSchemaField {
+	f.ReadOnly = true
+	return f
+}
+
+func atPath(path []string, fs ...types.LevelDatSchemaField) []types.LevelDatSchemaField {
+	out := make([]types.LevelDatSchemaField, 0, len(fs))
+	for _, f := range fs {
+		f.Path = append([]string{}, path...)
+		out = append(out, f)
+	}
+	return out
+}
+
+func opt(value, label string) types.LevelDatEnumOption {
+	return types.LevelDatEnumOption{Value: value, Label: label}
+}
+
+var broadcastOptions = []types.LevelDatEnumOption{
+	opt("0", "No Multiplayer"),
+	opt("1", "Invite Only"),
+	opt("2", "Friends Only"),
+	opt("3", "Friends of Friends"),
+	opt("4", "Public"),
+}
+
+var levelDatSchema = buildLevelDatSchema()
+
+func buildLevelDatSchema() []types.LevelDatSchemaField {
+	s := []types.LevelDatSchemaField{
+		gameRuleBool("commandblockoutput", "Command Block Output"),
+		gameRuleBool("commandblocksenabled", "Command Blocks Enabled"),
+		gameRuleBool("dodaylightcycle", "Do Daylight Cycle"),
+		gameRuleBool("doentitydrops", "Do Entity Drops"),
+		gameRuleBool("dofiretick", "Fire Spreads"),
+		gameRuleBool("doimmediaterespawn", "Immediate Respawn"),
+		gameRuleBool("doinsomnia", "Phantoms Spawn (Insomnia)"),
+		gameRuleBool("dolimitedcrafting", "Limited Crafting"),
+		gameRuleBool("domobloot", "Do Mob Loot"),
+		gameRuleBool("domobspawning", "Do Mob Spawning"),
+		gameRuleBool("dotiledrops", "Do Tile Drops"),
+		gameRuleBool("doweathercycle", "Do Weather Cycle"),
+		gameRuleBool("drowningdamage", "Drowning Damage"),
+		gameRuleBool("falldamage", "Fall Damage"),
+		gameRuleBool("firedamage", "Fire Damage"),
+		gameRuleBool("freezedamage", "Freeze Damage"),
+		gameRuleBool("keepinventory", "Keep Inventory"),
+		gameRuleBool("mobgriefing", "Mob Griefing"),
+		gameRuleBool("naturalregeneration", "Natural Regeneration"),
+		gameRuleBool("projectilescanbreakblocks", "Projectiles Can Break Blocks"),
+		gameRuleBool("pvp", "Friendly Fire (PvP)"),
+		gameRuleBool("recipesunlock", "Unlock Recipes"),
+		gameRuleBool("respawnblocksexplode", "Respawn Blocks Explode"),
+		gameRuleBool("sendcommandfeedback", "Send Command Feedback"),
+		gameRuleBool("showbordereffect", "Show Border Effect"),
+		gameRuleBool("showcoordinates", "Show Coordinates"),
+		gameRuleBool("showdaysplayed", "Show Days Played"),
+		gameRuleBool("showdeathmessages", "Show Death Messages"),
+		gameRuleBool("showrecipemessages", "Show Recipe Messages"),
+		gameRuleBool("showtags", "Show Item Tags"),
+		gameRuleBool("tntexplodes", "TNT Explodes"),
+		gameRuleBool("tntexplosiondropdecay", "TNT Explosion Drop Decay"),
+		gameRuleInt("functioncommandlimit", "Function Command Limit", float64Ptr(0), float64Ptr(10000)),
+		gameRuleInt("maxcommandchainlength", "Max Command Chain Length", float64Ptr(0), nil),
+		gameRuleInt("playerssleepingpercentage", "Players Sleeping Percentage", float64Ptr(0), float64Ptr(100)),
+		gameRuleInt("randomtickspeed", "Random Tick Speed", float64Ptr(0), float64Ptr(4096)),
+		gameRuleInt("spawnradius", "Respawn Radius", float64Ptr(0), float64Ptr(128)),
+
+		{Name: "LevelName", Tag: "string", Kind: LevelDatKindString, Category: LevelDatCategoryWorld, Label: "World Name"},
+		{Name: "RandomSeed", Tag: "long", Kind: LevelDatKindInt, Category: LevelDatCategoryWorld, Label: "Seed"},
+		settingEnum(LevelDatCategoryWorld, "GameType", "Game Mode",
+			opt("0", "Survival"), opt("1", "Creative"), opt("2", "Adventure"), opt("5", "Default"), opt("6", "Spectator")),
+		settingEnum(LevelDatCategoryWorld, "Difficulty", "Difficulty",
+			opt("0", "Peaceful"), opt("1", "Easy"), opt("2", "Normal"), opt("3", "Hard")),
+		readOnly(settingEnum(LevelDatCategoryWorld, "Generator", "World Type",
+			opt("0", "Legacy"), opt("1", "Infinite"), opt("2", "Flat"), opt("3", "Nether"), opt("4", "The End"), opt("5", "Void"))),
+		settingEnum(LevelDatCategoryWorld, "daylightCycle", "Daylight Cycle",
+			opt("0", "Normal"), opt("1", "Always Day"), opt("2", "Lock Time")),
+		settingBool(LevelDatCategoryWorld, "ForceGameType", "Force Game Mode"),
+		settingBool(LevelDatCategoryWorld, "isHardcore", "Hardcore"),
+		settingBool(LevelDatCategoryWorld, "bonusChestEnabled", "Bonus Chest"),
+		settingBool(LevelDatCategoryWorld, "startWithMapEnabled", "Starting Map"),
+		settingBool(LevelDatCategoryWorld, "spawnMobs", "Spawn Mobs"),
+		settingBool(LevelDatCategoryWorld, "texturePacksRequired", "Texture Packs Required"),
+		settingBool(LevelDatCategoryWorld, "immutableWorld", "Immutable World"),
+		settingBool(LevelDatCategoryWorld, "spawnV1Villagers", "Spawn V1 Villagers"),
+		settingBool(LevelDatCategoryWorld, "CenterMapsToOrigin", "Center Maps To Origin"),
+		settingBool(LevelDatCategoryWorld, "useMsaGamertagsOnly", "Xbox Live Gamertags Only"),
+		{Name: "Time", Tag: "long", Kind: LevelDatKindInt, Category: LevelDatCategoryWorld, Label: "Time of Day", Min: float64Ptr(0)},
+		{Name: "currentTick", Tag: "long", Kind: LevelDatKindInt, Category: LevelDatCategoryWorld, Label: "Current Tick", Min: float64Ptr(0)},
+		{Name: "serverChunkTickRange", Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategoryWorld, Label: "Simulation Distance", Min: float64Ptr(4), Max: float64Ptr(12)},
+
+		{Name: "SpawnX", Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategorySpawn, Label: "Spawn X"},
+		{Name: "SpawnY", Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategorySpawn, Label: "Spawn Y"},
+		{Name: "SpawnZ", Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategorySpawn, Label: "Spawn Z"},
+
+		settingBool(LevelDatCategoryCheats, "commandsEnabled", "Activate Cheats"),
+		settingBool(LevelDatCategoryCheats, "hasBeenLoadedInCreative", "Loaded In Creative (Achievements Disabled)"),
+
+		settingBool(LevelDatCategoryMultiplayer, "MultiplayerGame", "Multiplayer Game"),
+		settingBool(LevelDatCategoryMultiplayer, "MultiplayerGameIntent", "Multiplayer Game Intent"),
+		settingBool(LevelDatCategoryMultiplayer, "LANBroadcast", "Visible to LAN Players"),
+		settingBool(LevelDatCategoryMultiplayer, "LANBroadcastIntent", "LAN Broadcast Intent"),
+		settingEnum(LevelDatCategoryMultiplayer, "XBLBroadcastIntent", "Xbox Live Broadcast", broadcastOptions...),
+		settingEnum(LevelDatCategoryMultiplayer, "PlatformBroadcastIntent", "Platform Broadcast", broadcastOptions...),
+		settingEnum(LevelDatCategoryMultiplayer, "playerPermissionsLevel", "Default Player Permission",
+			opt("0", "Visitor"), opt("1", "Member"), opt("2", "Operator"), opt("3", "Custom")),
+		settingEnum(LevelDatCategoryMultiplayer, "permissionsLevel", "Permissions Level",
+			opt("0", "Any"), opt("1", "Game Directors"), opt("2", "Admin"), opt("3", "Host"), opt("4", "Owner"), opt("5", "Internal")),
+
+		settingBool(LevelDatCategoryEducation, "educationFeaturesEnabled", "Education Edition Features"),
+		settingEnum(LevelDatCategoryEducation, "eduOffer", "Education Offer",
+			opt("0", "None"), opt("1", "Rest of World"), opt("2", "China (Deprecated)")),
+
+		{Name: "rainLevel", Tag: "float", Kind: LevelDatKindFloat, Category: LevelDatCategoryWeather, Label: "Rain Level", Min: float64Ptr(0), Max: float64Ptr(1)},
+		{Name: "rainTime", Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategoryWeather, Label: "Rain Time", Min: float64Ptr(0)},
+		{Name: "lightningLevel", Tag: "float", Kind: LevelDatKindFloat, Category: LevelDatCategoryWeather, Label: "Lightning Level", Min: float64Ptr(0), Max: float64Ptr(1)},
+		{Name: "lightningTime", Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategoryWeather, Label: "Lightning Time", Min: float64Ptr(0)},
+
+		settingBool(LevelDatCategoryTemplate, "isFromWorldTemplate", "From World Template"),
+		settingBool(LevelDatCategoryTemplate, "isWorldTemplateOptionLocked", "World Template Options Locked"),
+		settingBool(LevelDatCategoryTemplate, "isFromLockedTemplate", "From Locked Template"),
+		settingBool(LevelDatCategoryTemplate, "hasLockedBehaviorPack", "Has Locked Behavior Pack"),
+		settingBool(LevelDatCategoryTemplate, "hasLockedResourcePack", "Has Locked Resource Pack"),
+		settingBool(LevelDatCategoryTemplate, "ConfirmedPlatformLockedContent", "Confirmed Platform Locked Content"),
+		settingBool(LevelDatCategoryTemplate, "requiresCopiedPackRemovalCheck", "Requires Copied Pack Removal Check"),
+
+		readOnly(types.LevelDatSchemaField{Name: "StorageVersion", Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategoryTechnical, Label: "Storage Version"}),
+		readOnly(types.LevelDatSchemaField{Name: "NetworkVersion", Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategoryTechnical, Label: "Network Protocol Version"}),
+		readOnly(types.LevelDatSchemaField{Name: "Platform", Tag: "int", Kind: LevelDatKindInt, Category: LevelDatCategoryTechnical, Label: "Platform"}),
+		readOnly(types.LevelDatSchemaField{Name: "WorldVersion", Tag: "int", Kind: LevelDatKindEnum, Category: LevelDatCategoryTechnical, Label: "World Version",
+			Enum: []types.LevelDatEnumOption{opt("0", "Pre 1.18"), opt("1", "Post 1.18")}}),
+		readOnly(types.LevelDatSchemaField{Name: "LastPlayed", Tag: "long", Kind: LevelDatKindInt, Category: LevelDatCategoryTechnical, Label: "Last Played"}),
+		readOnly(types.LevelDatSchemaField{Name: "worldStartCount", Tag: "long", Kind: LevelDatKindInt, Category: LevelDatCategoryTechnical, Label: "World Start Count"}),
+		readOnly(types.LevelDatSchemaField{Name: "InventoryVersion", Tag: "string", Kind: LevelDatKindString, Category: LevelDatCategoryTechnical, Label: "Inventory Version"}),
+		readOnly(types.LevelDatSchemaField{Name: "lastOpenedWithVersion", Tag: "list", Kind: LevelDatKindList, Category: LevelDatCategoryTechnical, Label: "Last Opened With Version"}),
+		readOnly(types.LevelDatSchemaField{Name: "MinimumCompatibleClientVersion", Tag: "list", Kind: LevelDatKindList, Category: LevelDatCategoryTechnical, Label: "Minimum Compatible Client Version"}),
+	}
+	s = append(s, atPath([]string{"abilities"},
+		settingBool(LevelDatCategoryAbilities, "attackmobs", "Attack Mobs"),
+		settingBool(LevelDatCategoryAbilities, "attackplayers", "Attack Players"),
+		settingBool(LevelDatCategoryAbilities, "build", "Build"),
+		settingBool(LevelDatCategoryAbilities, "doorsandswitches", "Use Doors and Switches"),
+		settingBool(LevelDatCategoryAbilities, "flying", "Flying"),
+		settingBool(LevelDatCategoryAbilities, "instabuild", "Instant Build"),
+		settingBool(LevelDatCategoryAbilities, "invulnerable", "Invulnerable"),
+		settingBool(LevelDatCategoryAbilities, "lightning", "Struck by Lightning"),
+		settingBool(LevelDatCategoryAbilities, "mayfly", "May Fly"),
+		settingBool(LevelDatCategoryAbilities, "mine", "Mine"),
+		settingBool(LevelDatCategoryAbilities, "op", "Operator Commands"),
+		settingBool(LevelDatCategoryAbilities, "opencontainers", "Open Containers"),
+		settingBool(LevelDatCategoryAbilities, "teleport", "Teleport"),
+		types.LevelDatSchemaField{Name: "flySpeed", Tag: "float", Kind: LevelDatKindFloat, Category: LevelDatCategoryAbilities, Label: "Fly Speed", Min: float64Ptr(0), Max: float64Ptr(10)},
+		types.LevelDatSchemaField{Name: "walkSpeed", Tag: "float", Kind: LevelDatKindFloat, Category: LevelDatCategoryAbilities, Label: "Walk Speed", Min: float64Ptr(0), Max: float64Ptr(10)},
+	)...)
+	return s
+}
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func GetLevelDatSchema() []types.LevelDatSchemaField {
+	out := make([]types.LevelDatSchemaField, len(levelDatSchema))
+	copy(out, levelDatSchema)
+	return out
+}
+
+func LookupLevelDatSchema(path []string, name string) (types.LevelDatSchemaField, bool) {
+	for _, f := range levelDatSchema {
+		if f.Name != name || len(f.Path) != len(path) {
+			continue
+		}
+		same := true
+		for i := range path {
+			if f.Path[i] != path[i] {
+				same = false
+				break
+			}
+		}
+		if same {
+			return f, true
+		}
+	}
+	return types.LevelDatSchemaField{}, false
+}
+
+func annotateLevelDatField(f *types.LevelDatField) {
+	sf, ok := LookupLevelDatSchema(f.Path, f.Name)
+	if !ok {
+		return
+	}
+	f.Known = true
+	f.IsBoolLike = sf.Kind == LevelDatKindBool
+}
+
+func ValidateLevelDatFields(path []string, fields []types.LevelDatField) []types.LevelDatFieldIssue {
+	var issues []types.LevelDatFieldIssue
+	for _, f := range fields {
+		sf, ok := LookupLevelDatSchema(path, f.Name)
+		if !ok {
+			continue
+		}
+		if code := validateLevelDatValue(sf, f); code != "" {
+			issues = append(issues, newLevelDatIssue(path, f, code))
+		}
+	}
+	return issues
+}
+
+func newLevelDatIssue(path []string, f types.LevelDatField, code string) types.LevelDatFieldIssue {
+	v := f.ValueString
+	if f.Tag == "list" || f.Tag == "compound" {
+		v = f.ValueJSON
+	}
+	return types.LevelDatFieldIssue{Name: f.Name, Path: append([]string{}, path...), Code: code, Value: v}
+}
+
+func validateLevelDatValue(sf types.LevelDatSchemaField, f types.LevelDatField) string {
+	if !strings.EqualFold(strings.TrimSpace(f.Tag), sf.Tag) {
+		return "ERR_LEVELDAT_TAG_MISMATCH"
+	}
+	raw := strings.TrimSpace(f.ValueString)
+	switch sf.Kind {
+	case LevelDatKindBool:
+		if raw != "0" && raw != "1" {
+			return "ERR_LEVELDAT_NOT_BOOL"
+		}
+	case LevelDatKindInt:
+		n, err := strconv.ParseInt(raw, 10, levelDatTagBits(sf.Tag))
+		if err != nil {
+			return "ERR_LEVELDAT_NOT_NUMBER"
+		}
+		if !levelDatInRange(sf, float64(n)) {
+			return "ERR_LEVELDAT_OUT_OF_RANGE"
+		}
+	case LevelDatKindFloat:
+		bits := 64
+		if sf.Tag == "float" {
+			bits = 32
+		}
+		n, err := strconv.ParseFloat(raw, bits)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return "ERR_LEVELDAT_NOT_NUMBER"
+		}
+		if !levelDatInRange(sf, n) {
+			return "ERR_LEVELDAT_OUT_OF_RANGE"
+		}
+	case LevelDatKindEnum:
+		n, err := strconv.ParseInt(raw, 10, levelDatTagBits(sf.Tag))
+		if err != nil {
+			return "ERR_LEVELDAT_NOT_NUMBER"
+		}
+		s := strconv.FormatInt(n, 10)
+		for _, o := range sf.Enum {
+			if o.Value == s {
+				return ""
+			}
+		}
+		return "ERR_LEVELDAT_NOT_IN_ENUM"
+	case LevelDatKindList:
+		var arr []any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(f.ValueJSON)), &arr); err != nil {
+			return "ERR_LEVELDAT_INVALID_JSON"
+		}
+	}
+	return ""
+}
+
+func levelDatTagBits(tag string) int {
+	switch tag {
+	case "byte":
+		return 8
+	case "short":
+		return 16
+	case "int":
+		return 32
+	default:
+		return 64
+	}
+}
+
+func levelDatInRange(sf types.LevelDatSchemaField, n float64) bool {
+	if sf.Min != nil && n < *sf.Min {
+		return false
+	}
+	if sf.Max != nil && n > *sf.Max {
+		return false
+	}
+	return true
+}
+
+func levelDatCurrentValue(v any) (string, bool) {
+	switch tv := v.(type) {
+	case int8, uint8, int16, int32, int64:
+		return fmt.Sprintf("%d", tv), true
+	case float32, float64:
+		return fmt.Sprintf("%g", tv), true
+	case string:
+		return tv, true
+	case nil:
+		return "", false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array || rv.Kind() == reflect.Slice {
+		return normalizeLevelDatJSON(v), true
+	}
+	return "", false
+}
+
+func normalizeLevelDatJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	var anyv any
+	if err := json.Unmarshal(b, &anyv); err != nil {
+		return string(b)
+	}
+	b, _ = json.Marshal(anyv)
+	return string(b)
+}
+
+func levelDatFieldUnchanged(cur map[string]any, f types.LevelDatField) bool {
+	old, ok := levelDatCurrentValue(cur[f.Name])
+	if !ok {
+		return false
+	}
+	if f.Tag == "list" {
+		var anyv any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(f.ValueJSON)), &anyv); err != nil {
+			return false
+		}
+		return normalizeLevelDatJSON(anyv) == old
+	}
+	return strings.TrimSpace(f.ValueString) == strings.TrimSpace(old)
+}
+
+func checkLevelDatWrite(path []string, cur map[string]any, fields []types.LevelDatField) error {
+	var issues []types.LevelDatFieldIssue
+	for _, f := range fields {
+		sf, ok := LookupLevelDatSchema(path, f.Name)
+		if !ok || levelDatFieldUnchanged(cur, f) {
+			continue
+		}
+		if sf.ReadOnly {
+			if _, exists := cur[f.Name]; exists {
+				issues = append(issues, newLevelDatIssue(path, f, "ERR_LEVELDAT_READ_ONLY"))
+				continue
+			}
+		}
+		if code := validateLevelDatValue(sf, f); code != "" {
+			issues = append(issues, newLevelDatIssue(path, f, code))
+		}
+	}
+	if len(issues) > 0 {
+		return &LevelDatValidationError{Issues: issues}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package content
+
+import (
+	"bytes"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/safezip"
+)
+
+const maxNestedArchiveDepth = 2
+
+func ReadArchiveManifests(data []byte) [][]byte {
+	return readArchiveManifests(bytes.NewReader(data), int64(len(data)), 0)
+}
+
+func ReadArchiveFileManifests(path string) [][]byte {
+	var out [][]byte
+	WithArchiveFile(path, func(r io.ReaderAt, size int64) string {
+		out = readArchiveManifests(r, size, 0)
+		return ""
+	})
+	return out
+}
+
+func readArchiveManifests(r io.ReaderAt, size int64, depth int) [][]byte {
+	zr, err := safezip.NewReader(r, size)
+	if err != nil {
+		return nil
+	}
+	var out [][]byte
+	for _, f := range zr.File {
+		name := normalizeZipEntryName(f.Name)
+		if strings.HasSuffix(name, "/") {
+			continue
+		}
+		lower := strings.ToLower(name)
+		if depth < maxNestedArchiveDepth && (strings.HasSuffix(lower, ".mcpack") || strings.HasSuffix(lower, ".mcaddon")) {
+			withZipEntryTempFile(f, func(nr io.ReaderAt, nsize int64) string {
+				out = append(out, readArchiveManifests(nr, nsize, depth+1)...)
+				return ""
+			})
+			continue
+		}
+		if !strings.EqualFold(path.Base(name), "manifest.json") {
+			continue
+		}
+		rc, er := f.Open()
+		if er != nil {
+			continue
+		}
+		b, er := io.ReadAll(rc)
+		_ = rc.Close()
+		if er == nil {
+			out = append(out, b)
+		}
+	}
+	return out
+}
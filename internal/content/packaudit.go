@@ -0,0 +1,291 @@
+package content
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	PackDuplicateUUID   = "duplicate_uuid"
+	PackVersionConflict = "version_conflict"
+)
+
+const (
+	FolderMismatchUUID    = "uuid"
+	FolderMismatchVersion = "version"
+)
+
+const (
+	ResolveKeepNewest   = "keep_newest"
+	ResolveKeepSpecific = "keep_specific"
+	ResolveMerge        = "merge"
+)
+
+var (
+	ErrInvalidResolveStrategy = errors.New("invalid resolve strategy")
+	ErrPackCopyNotFound       = errors.New("pack copy not found")
+	uuidInNameRe              = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	versionInNameRe           = regexp.MustCompile(`(?i)(?:^|[^0-9a-z.])v?(\d+(?:\.\d+){1,3})(?:[^0-9.]|$)`)
+)
+
+type auditedPack struct {
+	copy    types.PackCopy
+	uuid    string
+	version []int
+}
+
+func scanAuditedPacks(dirs ...string) []auditedPack {
+	var out []auditedPack
+	for _, root := range dirs {
+		if strings.TrimSpace(root) == "" || !utils.DirExists(root) {
+			continue
+		}
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			p := filepath.Join(root, e.Name())
+			mfPath := filepath.Join(p, "manifest.json")
+			b, err := os.ReadFile(mfPath)
+			if err != nil {
+				continue
+			}
+			var mf bedrockManifest
+			if err := json.Unmarshal(utils.JsonCompatBytes(b), &mf); err != nil {
+				continue
+			}
+			id := strings.ToLower(strings.TrimSpace(mf.Header.Uuid))
+			if id == "" {
+				continue
+			}
+			var mt int64
+			if fi, err := os.Stat(mfPath); err == nil {
+				mt = fi.ModTime().Unix()
+			}
+			name := mf.Header.Name
+			if texts := readPackTexts(p); texts != nil {
+				if v, ok := texts[name]; ok {
+					name = v
+				}
+			}
+			out = append(out, auditedPack{
+				copy: types.PackCopy{
+					Path:    p,
+					Folder:  e.Name(),
+					Name:    name,
+					Version: formatPackVersion(mf.Header.Version),
+					ModTime: mt,
+				},
+				uuid:    id,
+				version: mf.Header.Version,
+			})
+		}
+	}
+	return out
+}
+
+func formatPackVersion(v []int) string {
+	parts := make([]string, 0, len(v))
+	for _, n := range v {
+		parts = append(parts, strconv.Itoa(n))
+	}
+	return strings.Join(parts, ".")
+}
+
+func comparePackVersions(a []int, b []int) int {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func sortNewestFirst(copies []auditedPack) {
+	sort.SliceStable(copies, func(i, j int) bool {
+		if c := comparePackVersions(copies[i].version, copies[j].version); c != 0 {
+			return c > 0
+		}
+		return copies[i].copy.ModTime > copies[j].copy.ModTime
+	})
+}
+
+func AuditPacks(dirs ...string) types.PackAudit {
+	audit := types.PackAudit{Duplicates: []types.PackDuplicateGroup{}, Mismatches: []types.PackFolderMismatch{}}
+	packs := scanAuditedPacks(dirs...)
+	groups := map[string][]auditedPack{}
+	var order []string
+	for _, p := range packs {
+		if _, ok := groups[p.uuid]; !ok {
+			order = append(order, p.uuid)
+		}
+		groups[p.uuid] = append(groups[p.uuid], p)
+		if m, ok := folderMismatch(p); ok {
+			audit.Mismatches = append(audit.Mismatches, m)
+		}
+	}
+	for _, id := range order {
+		copies := groups[id]
+		if len(copies) < 2 {
+			continue
+		}
+		sortNewestFirst(copies)
+		g := types.PackDuplicateGroup{
+			UUID:   id,
+			Name:   copies[0].copy.Name,
+			Kind:   PackDuplicateUUID,
+			Newest: copies[0].copy.Path,
+		}
+		for _, c := range copies {
+			if comparePackVersions(c.version, copies[0].version) != 0 {
+				g.Kind = PackVersionConflict
+			}
+			g.Copies = append(g.Copies, c.copy)
+		}
+		audit.Duplicates = append(audit.Duplicates, g)
+	}
+	return audit
+}
+
+func folderMismatch(p auditedPack) (types.PackFolderMismatch, bool) {
+	m := types.PackFolderMismatch{
+		Path:    p.copy.Path,
+		Folder:  p.copy.Folder,
+		UUID:    p.uuid,
+		Name:    p.copy.Name,
+		Version: p.copy.Version,
+	}
+	folderUUID := uuidInNameRe.FindString(p.copy.Folder)
+	if folderUUID != "" && !strings.EqualFold(folderUUID, p.uuid) {
+		m.Reason = FolderMismatchUUID
+		m.FolderUUID = strings.ToLower(folderUUID)
+		return m, true
+	}
+	rest := uuidInNameRe.ReplaceAllString(p.copy.Folder, " ")
+	if sm := versionInNameRe.FindStringSubmatch(rest); sm != nil {
+		var fv []int
+		for _, part := range strings.Split(sm[1], ".") {
+			n, _ := strconv.Atoi(part)
+			fv = append(fv, n)
+		}
+		if comparePackVersions(fv, p.version) != 0 {
+			m.Reason = FolderMismatchVersion
+			m.FolderVersion = sm[1]
+			return m, true
+		}
+	}
+	return m, false
+}
+
+func ResolvePackDuplicates(uuid string, strategy string, keepPath string, remove func(path string) error, dirs ...string) error {
+	id := strings.ToLower(strings.TrimSpace(uuid))
+	var copies []auditedPack
+	for _, p := range scanAuditedPacks(dirs...) {
+		if p.uuid == id {
+			copies = append(copies, p)
+		}
+	}
+	if len(copies) == 0 {
+		return ErrPackCopyNotFound
+	}
+	sortNewestFirst(copies)
+	keep := 0
+	switch strategy {
+	case ResolveKeepNewest, ResolveMerge:
+	case ResolveKeepSpecific:
+		keep = -1
+		for i, c := range copies {
+			if strings.EqualFold(filepath.Clean(c.copy.Path), filepath.Clean(strings.TrimSpace(keepPath))) {
+				keep = i
+				break
+			}
+		}
+		if keep < 0 {
+			return ErrPackCopyNotFound
+		}
+	default:
+		return ErrInvalidResolveStrategy
+	}
+	kept := copies[keep].copy.Path
+	if strategy == ResolveMerge {
+		for _, c := range copies {
+			if err := CheckPackEditable(c.copy.Path); err != nil {
+				return err
+			}
+		}
+	}
+	for i, c := range copies {
+		if i == keep {
+			continue
+		}
+		if strategy == ResolveMerge {
+			if err := mergeMissingFiles(c.copy.Path, kept); err != nil {
+				return err
+			}
+		}
+		if err := remove(c.copy.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergeMissingFiles(src string, dst string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() || utils.FileExists(target) {
+			return nil
+		}
+		in, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, info.Mode())
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		_, err = io.Copy(out, in)
+		return err
+	})
+}
@@ -0,0 +1,386 @@
+package content
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/lang"
+	"github.com/liteldev/LeviLauncher/internal/safezip"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	DiffAdded    = "added"
+	DiffRemoved  = "removed"
+	DiffModified = "modified"
+
+	DiffKindJSON    = "json"
+	DiffKindTexture = "texture"
+	DiffKindOther   = "other"
+
+	maxDiffValueLen = 200
+)
+
+var diffDefinitionDirs = map[string]struct{}{"entities": {}, "entity": {}, "items": {}}
+
+type snapshotFile struct {
+	hash string
+	size int64
+	doc  any
+}
+
+type packSnapshot struct {
+	location string
+	uuid     string
+	name     string
+	version  []int
+	files    map[string]snapshotFile
+}
+
+func diffFileKind(p string) string {
+	ext := strings.ToLower(path.Ext(p))
+	switch {
+	case ext == ".json":
+		return DiffKindJSON
+	case strings.HasPrefix(strings.ToLower(p), "textures/"):
+		for _, t := range textureExts {
+			if ext == t {
+				return DiffKindTexture
+			}
+		}
+	}
+	return DiffKindOther
+}
+
+func isDefinitionFile(p string) bool {
+	if strings.EqualFold(p, "manifest.json") {
+		return true
+	}
+	top, _, _ := strings.Cut(strings.ToLower(p), "/")
+	_, ok := diffDefinitionDirs[top]
+	return ok && strings.EqualFold(path.Ext(p), ".json")
+}
+
+func snapshotPackFS(fsys fs.FS, location string) (packSnapshot, error) {
+	snap := packSnapshot{location: location, files: map[string]snapshotFile{}}
+	if err := checkPackEditableFS(fsys); err != nil {
+		return snap, err
+	}
+	if b, err := fs.ReadFile(fsys, "manifest.json"); err == nil {
+		var mf bedrockManifest
+		if json.Unmarshal(utils.JsonCompatBytes(b), &mf) == nil {
+			snap.uuid = strings.ToLower(strings.TrimSpace(mf.Header.Uuid))
+			snap.name = mf.Header.Name
+			snap.version = mf.Header.Version
+		}
+	}
+	if v, ok := lang.ReadPackTextsFS(fsys).Text(snap.name, lang.Current()); ok {
+		snap.name = v
+	}
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		f, err := fsys.Open(p)
+		if err != nil {
+			return err
+		}
+		h := sha256.New()
+		var buf bytes.Buffer
+		var w io.Writer = h
+		def := isDefinitionFile(p)
+		if def {
+			w = io.MultiWriter(h, &buf)
+		}
+		n, err := io.Copy(w, f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		sf := snapshotFile{hash: hex.EncodeToString(h.Sum(nil)), size: n}
+		if def {
+			var doc any
+			if json.Unmarshal(utils.JsonCompatBytes(buf.Bytes()), &doc) == nil {
+				sf.doc = doc
+			}
+		}
+		snap.files[p] = sf
+		return nil
+	})
+	return snap, err
+}
+
+func snapshotArchive(r io.ReaderAt, size int64, archiveName string, depth int) ([]packSnapshot, error) {
+	zr, err := safezip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	var out []packSnapshot
+	var roots []string
+	var nestedErr error
+	for _, f := range zr.File {
+		name := normalizeZipEntryName(f.Name)
+		lower := strings.ToLower(name)
+		switch {
+		case strings.EqualFold(path.Base(name), "manifest.json"):
+			roots = append(roots, path.Dir(name))
+		case depth < maxNestedArchiveDepth && (strings.HasSuffix(lower, ".mcpack") || strings.HasSuffix(lower, ".mcaddon")):
+			withZipEntryTempFile(f, func(nr io.ReaderAt, nsize int64) string {
+				snaps, err := snapshotArchive(nr, nsize, archiveName+"/"+name, depth+1)
+				if err != nil && nestedErr == nil {
+					nestedErr = err
+				}
+				out = append(out, snaps...)
+				return ""
+			})
+		}
+	}
+	if nestedErr != nil {
+		return nil, nestedErr
+	}
+	sort.Strings(roots)
+	for _, root := range roots {
+		sub, err := fs.Sub(zr, root)
+		if err != nil {
+			continue
+		}
+		loc := archiveName
+		if root != "." {
+			loc = archiveName + "/" + root
+		}
+		snap, err := snapshotPackFS(sub, loc)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, snap)
+	}
+	return out, nil
+}
+
+func snapshotPackPath(p string) ([]packSnapshot, error) {
+	if utils.IsDir(p) {
+		root := findManifestDir(p)
+		if root == "" {
+			root = p
+		}
+		snap, err := snapshotPackFS(os.DirFS(root), root)
+		if err != nil {
+			return nil, err
+		}
+		return []packSnapshot{snap}, nil
+	}
+	var snaps []packSnapshot
+	var serr error
+	code := WithArchiveFile(p, func(r io.ReaderAt, size int64) string {
+		snaps, serr = snapshotArchive(r, size, filepath.Base(p), 0)
+		return ""
+	})
+	if code != "" {
+		return nil, os.ErrNotExist
+	}
+	return snaps, serr
+}
+
+func diffErrorCode(err error) string {
+	if code := ProtectionErrorCode(err); code != "" {
+		return code
+	}
+	if code := safezip.ErrorCode(err); code != "" {
+		return code
+	}
+	if os.IsNotExist(err) {
+		return "ERR_NOT_FOUND"
+	}
+	return "ERR_READ_FILE"
+}
+
+func DiffPacks(oldPath string, newPath string) types.PackDiff {
+	res := types.PackDiff{Old: oldPath, New: newPath, Files: []types.PackFileChange{}}
+	olds, err := snapshotPackPath(oldPath)
+	if err != nil {
+		res.Error = diffErrorCode(err)
+		return res
+	}
+	news, err := snapshotPackPath(newPath)
+	if err != nil {
+		res.Error = diffErrorCode(err)
+		return res
+	}
+	if len(olds) == 0 || len(news) == 0 {
+		res.Error = "ERR_MANIFEST_NOT_FOUND"
+		return res
+	}
+	o, n := olds[0], news[0]
+	if len(olds) > 1 || len(news) > 1 {
+		matched := false
+		for _, a := range olds {
+			for _, b := range news {
+				if !matched && a.uuid != "" && a.uuid == b.uuid {
+					o, n, matched = a, b, true
+				}
+			}
+		}
+	}
+	return diffSnapshots(o, n)
+}
+
+func DiffPackUpdate(archivePath string, installedDir func(uuid string) string) []types.PackDiff {
+	out := []types.PackDiff{}
+	news, err := snapshotPackPath(archivePath)
+	if err != nil {
+		return append(out, types.PackDiff{New: archivePath, Files: []types.PackFileChange{}, Error: diffErrorCode(err)})
+	}
+	for _, n := range news {
+		o := packSnapshot{files: map[string]snapshotFile{}}
+		if dir := installedDir(n.uuid); dir != "" {
+			snap, err := snapshotPackFS(os.DirFS(dir), dir)
+			if err != nil {
+				out = append(out, types.PackDiff{Old: dir, New: n.location, UUID: n.uuid, Name: n.name, Files: []types.PackFileChange{}, Error: diffErrorCode(err)})
+				continue
+			}
+			o = snap
+		}
+		out = append(out, diffSnapshots(o, n))
+	}
+	return out
+}
+
+func diffSnapshots(o packSnapshot, n packSnapshot) types.PackDiff {
+	res := types.PackDiff{
+		Old:         o.location,
+		New:         n.location,
+		UUID:        n.uuid,
+		Name:        n.name,
+		OldVersion:  formatPackVersion(o.version),
+		NewVersion:  formatPackVersion(n.version),
+		VersionBump: versionBump(o.version, n.version),
+		UUIDChanged: o.uuid != "" && n.uuid != "" && o.uuid != n.uuid,
+		Files:       []types.PackFileChange{},
+	}
+	paths := map[string]struct{}{}
+	for p := range o.files {
+		paths[p] = struct{}{}
+	}
+	for p := range n.files {
+		paths[p] = struct{}{}
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+	for _, p := range sorted {
+		of, inOld := o.files[p]
+		nf, inNew := n.files[p]
+		c := types.PackFileChange{Path: p, Kind: diffFileKind(p), OldHash: of.hash, NewHash: nf.hash, OldSize: of.size, NewSize: nf.size}
+		switch {
+		case !inOld:
+			c.Change = DiffAdded
+			res.Added++
+		case !inNew:
+			c.Change = DiffRemoved
+			res.Removed++
+		case of.hash != nf.hash:
+			c.Change = DiffModified
+			res.Modified++
+			if of.doc != nil && nf.doc != nil {
+				c.JSONChanges = diffJSON("", of.doc, nf.doc, nil)
+			}
+		default:
+			continue
+		}
+		res.Files = append(res.Files, c)
+	}
+	return res
+}
+
+func versionBump(o []int, n []int) string {
+	get := func(v []int, i int) int {
+		if i < len(v) {
+			return v[i]
+		}
+		return 0
+	}
+	for i, level := range []string{"major", "minor", "patch"} {
+		a, b := get(o, i), get(n, i)
+		if b > a {
+			return level
+		}
+		if b < a {
+			return "downgrade"
+		}
+	}
+	return "none"
+}
+
+func diffValueString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	if len(b) > maxDiffValueLen {
+		return string(b[:maxDiffValueLen]) + "..."
+	}
+	return string(b)
+}
+
+func diffJSON(key string, a any, b any, out []types.PackJSONChange) []types.PackJSONChange {
+	am, aok := a.(map[string]any)
+	bm, bok := b.(map[string]any)
+	if aok && bok {
+		keys := make([]string, 0, len(am)+len(bm))
+		for k := range am {
+			keys = append(keys, k)
+		}
+		for k := range bm {
+			if _, ok := am[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := key + "/" + k
+			av, ina := am[k]
+			bv, inb := bm[k]
+			switch {
+			case !ina:
+				out = append(out, types.PackJSONChange{Key: child, Change: DiffAdded, New: diffValueString(bv)})
+			case !inb:
+				out = append(out, types.PackJSONChange{Key: child, Change: DiffRemoved, Old: diffValueString(av)})
+			default:
+				out = diffJSON(child, av, bv, out)
+			}
+		}
+		return out
+	}
+	as, aok := a.([]any)
+	bs, bok := b.([]any)
+	if aok && bok && len(as) == len(bs) {
+		for i := range as {
+			out = diffJSON(key+"/"+strconv.Itoa(i), as[i], bs[i], out)
+		}
+		return out
+	}
+	if ov, nv := diffValueString(a), diffValueString(b); ov != nv {
+		if key == "" {
+			key = "/"
+		}
+		out = append(out, types.PackJSONChange{Key: key, Change: DiffModified, Old: ov, New: nv})
+	}
+	return out
+}
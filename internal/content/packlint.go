@@ -0,0 +1,449 @@
+package content
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/safezip"
+	"github.com/liteldev/LeviLauncher/internal/scriptapi"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+var (
+	uuidFormatRe     = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	semverStringRe   = regexp.MustCompile(`^\d+\.\d+\.\d+([-+][0-9A-Za-z.-]+)?$`)
+	knownModuleTypes = map[string]struct{}{
+		"resources": {}, "data": {}, "script": {}, "client_data": {}, "interface": {},
+		"world_template": {}, "skin_pack": {}, "javascript": {},
+	}
+	textureExts = []string{".png", ".tga", ".jpg", ".jpeg"}
+)
+
+type packLinter struct {
+	fsys   fs.FS
+	report types.PackLintReport
+}
+
+func (l *packLinter) add(severity, code, file string, line, col int, format string, args ...any) {
+	l.report.Issues = append(l.report.Issues, types.PackLintIssue{
+		Severity: severity,
+		Code:     code,
+		File:     file,
+		Line:     line,
+		Column:   col,
+		Message:  fmt.Sprintf(format, args...),
+	})
+	if severity == LintError {
+		l.report.Errors++
+	} else {
+		l.report.Warnings++
+	}
+}
+
+func jsonErrorPosition(data []byte, err error) (int, int) {
+	var se *json.SyntaxError
+	var te *json.UnmarshalTypeError
+	var off int64 = -1
+	switch {
+	case errors.As(err, &se):
+		off = se.Offset
+	case errors.As(err, &te):
+		off = te.Offset
+	}
+	if off < 0 {
+		return 0, 0
+	}
+	if off > int64(len(data)) {
+		off = int64(len(data))
+	}
+	line, col := 1, 1
+	for _, b := range data[:off] {
+		if b == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
+
+func (l *packLinter) readJSON(file string, v any) bool {
+	b, err := fs.ReadFile(l.fsys, file)
+	if err != nil {
+		return false
+	}
+	aligned := utils.JsonCompatBytesAligned(b)
+	if err := json.Unmarshal(aligned, v); err != nil {
+		line, col := jsonErrorPosition(aligned, err)
+		l.add(LintError, "JSON_PARSE", file, line, col, "%s", err.Error())
+		return false
+	}
+	return true
+}
+
+func LintPackFS(fsys fs.FS, location string, gameVersion string) types.PackLintReport {
+	l := &packLinter{fsys: fsys, report: types.PackLintReport{Path: location, GameVersion: gameVersion, Issues: []types.PackLintIssue{}}}
+	var mf map[string]any
+	if _, err := fs.Stat(fsys, "manifest.json"); err != nil {
+		l.add(LintError, "MANIFEST_MISSING", "manifest.json", 0, 0, "manifest.json not found")
+		return l.report
+	}
+	switch err := checkPackEditableFS(fsys); err {
+	case ErrPackEncrypted:
+		l.add(LintError, "PACK_ENCRYPTED", "contents.json", 0, 0, "pack contents are encrypted Marketplace content and cannot be linted")
+		return l.report
+	case ErrPackSigned:
+		l.add(LintError, "PACK_SIGNED", "signatures.json", 0, 0, "pack is signed Marketplace content and cannot be linted")
+		return l.report
+	}
+	if l.readJSON("manifest.json", &mf) {
+		l.lintManifest(mf, gameVersion)
+	}
+	l.lintJSONFiles()
+	if _, err := fs.Stat(fsys, "pack_icon.png"); err != nil {
+		l.add(LintWarning, "ICON_MISSING", "pack_icon.png", 0, 0, "pack_icon.png not found")
+	}
+	l.lintTextureRefs("textures/terrain_texture.json")
+	l.lintTextureRefs("textures/item_texture.json")
+	return l.report
+}
+
+func parseVersionValue(v any) ([]int, bool) {
+	switch t := v.(type) {
+	case []any:
+		if len(t) != 3 {
+			return nil, false
+		}
+		out := make([]int, 3)
+		for i, e := range t {
+			f, ok := e.(float64)
+			if !ok || f < 0 || f != float64(int(f)) {
+				return nil, false
+			}
+			out[i] = int(f)
+		}
+		return out, true
+	case string:
+		if !semverStringRe.MatchString(t) {
+			return nil, false
+		}
+		core := strings.SplitN(strings.SplitN(t, "-", 2)[0], "+", 2)[0]
+		parts := strings.Split(core, ".")
+		out := make([]int, 3)
+		for i := range out {
+			out[i], _ = strconv.Atoi(parts[i])
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func (l *packLinter) lintScriptModule(i int, module string, v any, gameVersion string) {
+	module = strings.ToLower(strings.TrimSpace(module))
+	if !strings.HasPrefix(module, "@minecraft/") {
+		return
+	}
+	version, ok := v.(string)
+	if !ok {
+		parts, _ := parseVersionValue(v)
+		version = formatPackVersion(parts)
+	}
+	table, _ := scriptapi.Load()
+	switch table.Check(module, version, gameVersion) {
+	case scriptapi.StatusUnsupported:
+		l.add(LintError, "SCRIPT_MODULE_UNSUPPORTED", "manifest.json", 0, 0, "dependencies[%d] %s %s does not load on game version %s", i, module, version, gameVersion)
+	case scriptapi.StatusUnknownVersion, scriptapi.StatusUnknownGame:
+		l.add(LintWarning, "SCRIPT_MODULE_UNKNOWN", "manifest.json", 0, 0, "dependencies[%d] %s %s is not in the script module table for game version %s", i, module, version, gameVersion)
+	}
+}
+
+func (l *packLinter) lintManifest(mf map[string]any, gameVersion string) {
+	const file = "manifest.json"
+	fv, ok := mf["format_version"].(float64)
+	switch {
+	case !ok:
+		l.add(LintError, "FORMAT_VERSION", file, 0, 0, "format_version is missing or not a number")
+	case fv != 1 && fv != 2 && fv != 3:
+		l.add(LintError, "FORMAT_VERSION", file, 0, 0, "unsupported format_version %v", fv)
+	}
+	header, _ := mf["header"].(map[string]any)
+	if header == nil {
+		l.add(LintError, "HEADER_MISSING", file, 0, 0, "header is missing")
+		return
+	}
+	name, _ := header["name"].(string)
+	l.report.Name = name
+	if strings.TrimSpace(name) == "" {
+		l.add(LintError, "HEADER_NAME", file, 0, 0, "header.name is missing")
+	}
+	headerUUID, _ := header["uuid"].(string)
+	l.report.UUID = strings.ToLower(headerUUID)
+	if !uuidFormatRe.MatchString(headerUUID) {
+		l.add(LintError, "UUID_FORMAT", file, 0, 0, "header.uuid %q is not a valid UUID", headerUUID)
+	}
+	if _, ok := parseVersionValue(header["version"]); !ok {
+		l.add(LintError, "VERSION_FORMAT", file, 0, 0, "header.version must be an array of three non-negative integers")
+	}
+	if raw, present := header["min_engine_version"]; present {
+		mev, ok := parseVersionValue(raw)
+		if !ok {
+			l.add(LintError, "MIN_ENGINE_VERSION", file, 0, 0, "header.min_engine_version must be an array of three non-negative integers")
+		} else {
+			l.report.MinEngineVersion = formatPackVersion(mev)
+			if gv := strings.TrimSpace(gameVersion); gv != "" && compareEngineVersion(mev, gv) > 0 {
+				l.add(LintError, "ENGINE_TOO_NEW", file, 0, 0, "pack requires engine %s but the selected version is %s", l.report.MinEngineVersion, gv)
+			}
+		}
+	} else if fv >= 2 {
+		l.add(LintWarning, "MIN_ENGINE_VERSION", file, 0, 0, "header.min_engine_version is missing")
+	}
+	modules, _ := mf["modules"].([]any)
+	if len(modules) == 0 {
+		l.add(LintError, "MODULES_MISSING", file, 0, 0, "modules is missing or empty")
+	}
+	seen := map[string]struct{}{strings.ToLower(headerUUID): {}}
+	for i, m := range modules {
+		mod, _ := m.(map[string]any)
+		if mod == nil {
+			l.add(LintError, "MODULE_INVALID", file, 0, 0, "modules[%d] is not an object", i)
+			continue
+		}
+		tp, _ := mod["type"].(string)
+		if _, ok := knownModuleTypes[strings.ToLower(tp)]; !ok {
+			l.add(LintError, "MODULE_TYPE", file, 0, 0, "modules[%d].type %q is not a known module type", i, tp)
+		}
+		id, _ := mod["uuid"].(string)
+		if !uuidFormatRe.MatchString(id) {
+			l.add(LintError, "UUID_FORMAT", file, 0, 0, "modules[%d].uuid %q is not a valid UUID", i, id)
+		} else if _, dup := seen[strings.ToLower(id)]; dup {
+			l.add(LintError, "UUID_REUSED", file, 0, 0, "modules[%d].uuid %q duplicates another UUID in this manifest", i, id)
+		}
+		seen[strings.ToLower(id)] = struct{}{}
+		if _, ok := parseVersionValue(mod["version"]); !ok {
+			l.add(LintError, "VERSION_FORMAT", file, 0, 0, "modules[%d].version must be an array of three non-negative integers", i)
+		}
+	}
+	deps, _ := mf["dependencies"].([]any)
+	for i, d := range deps {
+		dep, _ := d.(map[string]any)
+		if dep == nil {
+			l.add(LintError, "DEPENDENCY_INVALID", file, 0, 0, "dependencies[%d] is not an object", i)
+			continue
+		}
+		id, hasID := dep["uuid"].(string)
+		module, hasModule := dep["module_name"].(string)
+		switch {
+		case hasID && !uuidFormatRe.MatchString(id):
+			l.add(LintError, "UUID_FORMAT", file, 0, 0, "dependencies[%d].uuid %q is not a valid UUID", i, id)
+		case !hasID && !hasModule:
+			l.add(LintError, "DEPENDENCY_INVALID", file, 0, 0, "dependencies[%d] needs a uuid or module_name", i)
+		}
+		if _, ok := parseVersionValue(dep["version"]); !ok {
+			l.add(LintError, "VERSION_FORMAT", file, 0, 0, "dependencies[%d].version is not a valid version", i)
+		} else if hasModule && gameVersion != "" {
+			l.lintScriptModule(i, module, dep["version"], gameVersion)
+		}
+	}
+	subpacks, _ := mf["subpacks"].([]any)
+	folders := map[string]struct{}{}
+	for i, s := range subpacks {
+		sp, _ := s.(map[string]any)
+		folder, _ := sp["folder_name"].(string)
+		folder = strings.TrimSpace(folder)
+		if folder == "" {
+			l.add(LintError, "SUBPACK_INVALID", file, 0, 0, "subpacks[%d].folder_name is missing", i)
+			continue
+		}
+		if _, dup := folders[strings.ToLower(folder)]; dup {
+			l.add(LintError, "SUBPACK_DUPLICATE", file, 0, 0, "subpacks[%d].folder_name %q is declared twice", i, folder)
+		}
+		folders[strings.ToLower(folder)] = struct{}{}
+		if fi, err := fs.Stat(l.fsys, "subpacks/"+folder); err != nil || !fi.IsDir() {
+			l.add(LintError, "SUBPACK_MISSING", file, 0, 0, "subpacks[%d] folder subpacks/%s does not exist", i, folder)
+		}
+		if _, ok := sp["memory_tier"].(float64); !ok {
+			l.add(LintWarning, "SUBPACK_MEMORY_TIER", file, 0, 0, "subpacks[%d].memory_tier is missing", i)
+		}
+	}
+}
+
+func compareEngineVersion(mev []int, gameVersion string) int {
+	parts := strings.Split(gameVersion, ".")
+	for i := 0; i < 3; i++ {
+		var g int
+		if i < len(parts) {
+			g, _ = strconv.Atoi(parts[i])
+		}
+		if mev[i] != g {
+			if mev[i] < g {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func (l *packLinter) lintJSONFiles() {
+	var files []string
+	_ = fs.WalkDir(l.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() && p != "manifest.json" && strings.EqualFold(path.Ext(p), ".json") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	for _, f := range files {
+		var v any
+		l.readJSON(f, &v)
+	}
+}
+
+func (l *packLinter) textureExists(ref string) bool {
+	ref = strings.TrimPrefix(strings.ReplaceAll(ref, "\\", "/"), "/")
+	if path.Ext(ref) != "" {
+		if _, err := fs.Stat(l.fsys, ref); err == nil {
+			return true
+		}
+	}
+	for _, ext := range textureExts {
+		if _, err := fs.Stat(l.fsys, ref+ext); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *packLinter) lintTextureRefs(file string) {
+	b, err := fs.ReadFile(l.fsys, file)
+	if err != nil {
+		return
+	}
+	var doc struct {
+		TextureData map[string]struct {
+			Textures any `json:"textures"`
+		} `json:"texture_data"`
+	}
+	if json.Unmarshal(utils.JsonCompatBytes(b), &doc) != nil {
+		return
+	}
+	keys := make([]string, 0, len(doc.TextureData))
+	for k := range doc.TextureData {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, ref := range textureRefs(doc.TextureData[k].Textures) {
+			if !l.textureExists(ref) {
+				l.add(LintWarning, "TEXTURE_MISSING", file, 0, 0, "texture %q referenced by %q not found", ref, k)
+			}
+		}
+	}
+}
+
+func textureRefs(v any) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case map[string]any:
+		if p, ok := t["path"].(string); ok {
+			return []string{p}
+		}
+	case []any:
+		var out []string
+		for _, e := range t {
+			out = append(out, textureRefs(e)...)
+		}
+		return out
+	}
+	return nil
+}
+
+func LintPackDir(dir string, gameVersion string) types.PackLintReport {
+	root := findManifestDir(dir)
+	if root == "" {
+		root = dir
+	}
+	return LintPackFS(os.DirFS(root), root, gameVersion)
+}
+
+func LintPackArchive(data []byte, archiveName string, gameVersion string) []types.PackLintReport {
+	return lintArchive(bytes.NewReader(data), int64(len(data)), archiveName, gameVersion, 0)
+}
+
+func LintPackArchiveFile(path string, gameVersion string) []types.PackLintReport {
+	var out []types.PackLintReport
+	code := WithArchiveFile(path, func(r io.ReaderAt, size int64) string {
+		out = lintArchive(r, size, filepath.Base(path), gameVersion, 0)
+		return ""
+	})
+	if code != "" {
+		r := types.PackLintReport{Path: path, GameVersion: gameVersion, Errors: 1}
+		r.Issues = []types.PackLintIssue{{Severity: LintError, Code: "ARCHIVE_INVALID", File: filepath.Base(path), Message: "cannot open archive"}}
+		out = append(out, r)
+	}
+	return out
+}
+
+func lintArchive(r io.ReaderAt, size int64, archiveName string, gameVersion string, depth int) []types.PackLintReport {
+	out := []types.PackLintReport{}
+	zr, err := safezip.NewReader(r, size)
+	if err != nil {
+		r := types.PackLintReport{Path: archiveName, GameVersion: gameVersion, Issues: []types.PackLintIssue{}}
+		r.Issues = append(r.Issues, types.PackLintIssue{Severity: LintError, Code: "ARCHIVE_INVALID", File: archiveName, Message: err.Error()})
+		r.Errors = 1
+		return append(out, r)
+	}
+	var roots []string
+	for _, f := range zr.File {
+		name := normalizeZipEntryName(f.Name)
+		lower := strings.ToLower(name)
+		switch {
+		case strings.EqualFold(path.Base(name), "manifest.json"):
+			roots = append(roots, path.Dir(name))
+		case depth < maxNestedArchiveDepth && (strings.HasSuffix(lower, ".mcpack") || strings.HasSuffix(lower, ".mcaddon")):
+			withZipEntryTempFile(f, func(nr io.ReaderAt, nsize int64) string {
+				out = append(out, lintArchive(nr, nsize, archiveName+"/"+name, gameVersion, depth+1)...)
+				return ""
+			})
+		}
+	}
+	sort.Strings(roots)
+	for _, r := range roots {
+		sub, err := fs.Sub(zr, r)
+		if err != nil {
+			continue
+		}
+		loc := archiveName
+		if r != "." {
+			loc = archiveName + "/" + r
+		}
+		out = append(out, LintPackFS(sub, loc, gameVersion))
+	}
+	if len(out) == 0 {
+		out = append(out, LintPackFS(zr, archiveName, gameVersion))
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package content
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/lang"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+func PackTranslationCoverage(dir string) types.PackTranslationCoverage {
+	res := types.PackTranslationCoverage{Path: dir, Current: lang.Current(), Languages: []types.PackLanguageCoverage{}}
+	if !utils.IsDir(dir) {
+		res.Error = "ERR_NOT_FOUND"
+		return res
+	}
+	var nameKey, descKey string
+	if b, err := os.ReadFile(filepath.Join(dir, "manifest.json")); err == nil {
+		var mf bedrockManifest
+		if json.Unmarshal(utils.JsonCompatBytes(b), &mf) == nil {
+			nameKey = strings.TrimSpace(mf.Header.Name)
+			descKey = strings.TrimSpace(mf.Header.Description)
+		}
+	}
+	texts := lang.ReadPackTexts(dir)
+
+	keySet := map[string]struct{}{}
+	for _, entries := range texts.Entries {
+		for k := range entries {
+			keySet[k] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for k := range keySet {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	res.Keys = len(keys)
+
+	declared := map[string]bool{}
+	for _, c := range texts.Declared {
+		declared[c] = true
+	}
+	codes := append([]string{}, texts.Languages...)
+	for _, c := range texts.Declared {
+		if _, ok := texts.Entries[c]; !ok {
+			codes = append(codes, c)
+		}
+	}
+	for _, code := range codes {
+		entries, hasFile := texts.Entries[code]
+		cov := types.PackLanguageCoverage{
+			Language: code,
+			Declared: declared[code],
+			HasFile:  hasFile,
+			Total:    len(keys),
+			Missing:  []string{},
+		}
+		for _, k := range keys {
+			if v, ok := entries[k]; ok && v != "" {
+				cov.Translated++
+			} else {
+				cov.Missing = append(cov.Missing, k)
+			}
+		}
+		if cov.Total > 0 {
+			cov.Percent = math.Round(float64(cov.Translated)*10000/float64(cov.Total)) / 100
+		}
+		cov.Name = texts.Localize(nameKey, code)
+		cov.Description = texts.Localize(descKey, code)
+		res.Languages = append(res.Languages, cov)
+	}
+	return res
+}
@@ -0,0 +1,41 @@
+package content
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/liteldev/LeviLauncher/internal/packages"
+)
+
+var (
+	ErrPackEncrypted = errors.New("pack contents are encrypted")
+	ErrPackSigned    = errors.New("pack is signed marketplace content")
+)
+
+func protectionError(protection string) error {
+	switch protection {
+	case packages.ProtectionEncryptedContents, packages.ProtectionEncryptedKeys:
+		return ErrPackEncrypted
+	case packages.ProtectionSigned:
+		return ErrPackSigned
+	}
+	return nil
+}
+
+func CheckPackEditable(dir string) error {
+	return protectionError(packages.DetectProtection(dir))
+}
+
+func checkPackEditableFS(fsys fs.FS) error {
+	return protectionError(packages.DetectProtectionFS(fsys))
+}
+
+func ProtectionErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrPackEncrypted):
+		return "ERR_PACK_ENCRYPTED"
+	case errors.Is(err, ErrPackSigned):
+		return "ERR_PACK_SIGNED"
+	}
+	return ""
+}
@@ -0,0 +1,257 @@
+package content
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/lang"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	ScaffoldResource = "resource"
+	ScaffoldBehavior = "behavior"
+	ScaffoldAddon    = "addon"
+
+	DefaultScriptServerVersion = "1.11.0"
+	defaultScriptEntry         = "scripts/main.js"
+	scaffoldIconSize           = 64
+)
+
+var (
+	defaultMinEngineVersion = []int{1, 21, 0}
+	scriptVersionPattern    = regexp.MustCompile(`^\d+\.\d+\.\d+(-(beta|rc|alpha)(\.[0-9A-Za-z.-]+)?)?$`)
+)
+
+type scaffoldHalf struct {
+	kind       string
+	dir        string
+	uuid       string
+	moduleUUID string
+}
+
+func scaffoldIcon(seed string) ([]byte, error) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(seed))
+	sum := h.Sum32()
+	bg := color.RGBA{R: uint8(sum>>16)&0x7f + 0x40, G: uint8(sum>>8)&0x7f + 0x40, B: uint8(sum)&0x7f + 0x40, A: 0xff}
+	fg := color.RGBA{R: bg.R / 2, G: bg.G / 2, B: bg.B / 2, A: 0xff}
+	img := image.NewRGBA(image.Rect(0, 0, scaffoldIconSize, scaffoldIconSize))
+	for y := 0; y < scaffoldIconSize; y++ {
+		for x := 0; x < scaffoldIconSize; x++ {
+			c := bg
+			if x < 4 || y < 4 || x >= scaffoldIconSize-4 || y >= scaffoldIconSize-4 || ((x/8)+(y/8))%2 == 0 && x >= 16 && x < 48 && y >= 16 && y < 48 {
+				c = fg
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func scaffoldManifest(req types.PackScaffoldRequest, half scaffoldHalf, partner *scaffoldHalf, scriptVersion string, scriptEntry string) map[string]any {
+	version := []int{1, 0, 0}
+	modules := []any{}
+	switch half.kind {
+	case ScaffoldResource:
+		modules = append(modules, map[string]any{"type": "resources", "uuid": half.moduleUUID, "version": version})
+	case ScaffoldBehavior:
+		modules = append(modules, map[string]any{"type": "data", "uuid": half.moduleUUID, "version": version})
+	}
+	deps := []any{}
+	if partner != nil {
+		deps = append(deps, map[string]any{"uuid": partner.uuid, "version": version})
+	}
+	if half.kind == ScaffoldBehavior && scriptVersion != "" {
+		modules = append(modules, map[string]any{
+			"type":     "script",
+			"language": "javascript",
+			"uuid":     newPackUUID(),
+			"entry":    scriptEntry,
+			"version":  version,
+		})
+		deps = append(deps, map[string]any{"module_name": "@minecraft/server", "version": scriptVersion})
+	}
+	minEngine := req.MinEngineVersion
+	if len(minEngine) != 3 {
+		minEngine = defaultMinEngineVersion
+	}
+	m := map[string]any{
+		"format_version": 2,
+		"header": map[string]any{
+			"name":               "pack.name",
+			"description":        "pack.description",
+			"uuid":               half.uuid,
+			"version":            version,
+			"min_engine_version": minEngine,
+		},
+		"modules": modules,
+	}
+	if len(deps) > 0 {
+		m["dependencies"] = deps
+	}
+	return m
+}
+
+func writeScaffoldHalf(req types.PackScaffoldRequest, half scaffoldHalf, partner *scaffoldHalf, scriptVersion string, scriptEntry string) error {
+	if err := os.MkdirAll(filepath.Join(half.dir, "texts"), 0755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(scaffoldManifest(req, half, partner, scriptVersion, scriptEntry), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(half.dir, "manifest.json"), b, 0644); err != nil {
+		return err
+	}
+	name := strings.TrimSpace(req.Name)
+	if strings.TrimSpace(req.Kind) == ScaffoldAddon {
+		if half.kind == ScaffoldResource {
+			name += " RP"
+		} else {
+			name += " BP"
+		}
+	}
+	entries := map[string]string{"pack.name": name, "pack.description": strings.TrimSpace(req.Description)}
+	if err := writeLangFile(filepath.Join(half.dir, "texts", lang.DefaultLanguage+".lang"), []string{"pack.name", "pack.description"}, entries); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(half.dir, "texts", "languages.json"), []byte(`["`+lang.DefaultLanguage+`"]`+"\n"), 0644); err != nil {
+		return err
+	}
+	icon, err := scaffoldIcon(half.uuid)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(half.dir, "pack_icon.png"), icon, 0644); err != nil {
+		return err
+	}
+	switch half.kind {
+	case ScaffoldResource:
+		for _, d := range []string{"textures", "models/entity", "entity"} {
+			if err := os.MkdirAll(filepath.Join(half.dir, filepath.FromSlash(d)), 0755); err != nil {
+				return err
+			}
+		}
+	case ScaffoldBehavior:
+		for _, d := range []string{"entities", "items", "functions"} {
+			if err := os.MkdirAll(filepath.Join(half.dir, d), 0755); err != nil {
+				return err
+			}
+		}
+		if scriptVersion != "" {
+			entry := filepath.Join(half.dir, filepath.FromSlash(scriptEntry))
+			if err := os.MkdirAll(filepath.Dir(entry), 0755); err != nil {
+				return err
+			}
+			js := fmt.Sprintf("import { world, system } from \"@minecraft/server\";\n\nsystem.run(() => {\n  world.sendMessage(%q);\n});\n", name+" loaded")
+			if err := os.WriteFile(entry, []byte(js), 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func ScaffoldPack(req types.PackScaffoldRequest) types.PackScaffoldResult {
+	res := types.PackScaffoldResult{Packs: []types.ScaffoldedPack{}}
+	kind := strings.ToLower(strings.TrimSpace(req.Kind))
+	req.Kind = kind
+	name := strings.TrimSpace(req.Name)
+	out := strings.TrimSpace(req.OutputDir)
+	if name == "" {
+		res.Error = "ERR_INVALID_NAME"
+		return res
+	}
+	if out == "" {
+		res.Error = "ERR_INVALID_PATH"
+		return res
+	}
+	var kinds []string
+	switch kind {
+	case ScaffoldResource, ScaffoldBehavior:
+		kinds = []string{kind}
+	case ScaffoldAddon:
+		kinds = []string{ScaffoldBehavior, ScaffoldResource}
+	default:
+		res.Error = "ERR_INVALID_PACKAGE"
+		return res
+	}
+	scriptVersion := ""
+	scriptEntry := ""
+	if req.ScriptModule {
+		if kind == ScaffoldResource {
+			res.Error = "ERR_SCRIPT_REQUIRES_BEHAVIOR"
+			return res
+		}
+		scriptVersion = strings.TrimSpace(req.ScriptVersion)
+		if scriptVersion == "" {
+			scriptVersion = DefaultScriptServerVersion
+		}
+		if !scriptVersionPattern.MatchString(scriptVersion) {
+			res.Error = "ERR_INVALID_SCRIPT_VERSION"
+			return res
+		}
+		scriptEntry = path.Clean(strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(req.ScriptEntry)), "/"))
+		if scriptEntry == "." || scriptEntry == "" {
+			scriptEntry = defaultScriptEntry
+		}
+		if strings.HasPrefix(scriptEntry, "../") || !strings.HasSuffix(strings.ToLower(scriptEntry), ".js") {
+			res.Error = "ERR_INVALID_PATH"
+			return res
+		}
+	}
+	folder := utils.SanitizeFilename(name)
+	if folder == "" {
+		res.Error = "ERR_INVALID_NAME"
+		return res
+	}
+	halves := make([]scaffoldHalf, 0, len(kinds))
+	for _, k := range kinds {
+		dirName := folder
+		if kind == ScaffoldAddon {
+			if k == ScaffoldResource {
+				dirName += " RP"
+			} else {
+				dirName += " BP"
+			}
+		}
+		dir := filepath.Join(out, dirName)
+		if _, err := os.Lstat(dir); err == nil {
+			res.Error = "ERR_TARGET_EXISTS"
+			return res
+		}
+		halves = append(halves, scaffoldHalf{kind: k, dir: dir, uuid: newPackUUID(), moduleUUID: newPackUUID()})
+	}
+	for i := range halves {
+		var partner *scaffoldHalf
+		if len(halves) == 2 {
+			partner = &halves[1-i]
+		}
+		if err := writeScaffoldHalf(req, halves[i], partner, scriptVersion, scriptEntry); err != nil {
+			for _, h := range halves {
+				_ = os.RemoveAll(h.dir)
+			}
+			res.Error = "ERR_WRITE_FILE"
+			return res
+		}
+		res.Packs = append(res.Packs, types.ScaffoldedPack{Kind: halves[i].kind, Path: halves[i].dir, UUID: halves[i].uuid, ModuleUUID: halves[i].moduleUUID})
+	}
+	return res
+}
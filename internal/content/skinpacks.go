@@ -0,0 +1,438 @@
+package content
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/png"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/lang"
+	"github.com/liteldev/LeviLauncher/internal/safezip"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	SkinGeometryCustom     = "geometry.humanoid.custom"
+	SkinGeometryCustomSlim = "geometry.humanoid.customSlim"
+)
+
+type skinsJSON struct {
+	Skins []struct {
+		LocalizationName string `json:"localization_name"`
+		Geometry         string `json:"geometry"`
+		Texture          string `json:"texture"`
+		Type             string `json:"type"`
+	} `json:"skins"`
+	SerializeName    string `json:"serialize_name"`
+	LocalizationName string `json:"localization_name"`
+}
+
+func ReadSkinPackFS(fsys fs.FS, location string) types.SkinPackInfo {
+	info := types.SkinPackInfo{Path: location, Names: map[string]string{}, Geometries: []string{}, Skins: []types.SkinInfo{}}
+	if b, err := fs.ReadFile(fsys, "manifest.json"); err == nil {
+		var mf bedrockManifest
+		if json.Unmarshal(utils.JsonCompatBytes(b), &mf) == nil {
+			info.UUID = strings.TrimSpace(mf.Header.Uuid)
+			info.Version = formatPackVersion(mf.Header.Version)
+		}
+	}
+	b, err := fs.ReadFile(fsys, "skins.json")
+	if err != nil {
+		info.Error = "ERR_SKINS_JSON_MISSING"
+		return info
+	}
+	var sj skinsJSON
+	if err := json.Unmarshal(utils.JsonCompatBytes(b), &sj); err != nil {
+		info.Error = "ERR_SKINS_JSON_INVALID"
+		return info
+	}
+	texts := lang.ReadPackTextsFS(fsys)
+	current := lang.Current()
+	info.SerializeName = sj.SerializeName
+	info.LocalizationName = sj.LocalizationName
+	packKey := "skinpack." + sj.LocalizationName
+	info.Name = texts.Localize(packKey, current)
+	if info.Name == packKey {
+		info.Name = sj.LocalizationName
+	}
+	for _, code := range texts.Languages {
+		if v, ok := texts.Entries[code][packKey]; ok {
+			info.Names[code] = v
+		}
+	}
+	info.Geometries = readSkinGeometries(fsys)
+	for _, s := range sj.Skins {
+		skin := types.SkinInfo{
+			LocalizationName: s.LocalizationName,
+			Names:            map[string]string{},
+			Geometry:         s.Geometry,
+			Slim:             s.Geometry == SkinGeometryCustomSlim,
+			Texture:          s.Texture,
+			Type:             s.Type,
+		}
+		key := "skin." + sj.LocalizationName + "." + s.LocalizationName
+		skin.Name = texts.Localize(key, current)
+		if skin.Name == key {
+			skin.Name = s.LocalizationName
+		}
+		for _, code := range texts.Languages {
+			if v, ok := texts.Entries[code][key]; ok {
+				skin.Names[code] = v
+			}
+		}
+		if tex := strings.TrimSpace(s.Texture); tex != "" {
+			if tb, err := fs.ReadFile(fsys, path.Clean(strings.ReplaceAll(tex, "\\", "/"))); err == nil {
+				if cfg, _, err := image.DecodeConfig(bytes.NewReader(tb)); err == nil {
+					skin.TextureWidth = cfg.Width
+					skin.TextureHeight = cfg.Height
+				}
+				skin.TextureDataUrl = "data:image/png;base64," + base64.StdEncoding.EncodeToString(tb)
+			}
+		}
+		info.Skins = append(info.Skins, skin)
+	}
+	return info
+}
+
+func readSkinGeometries(fsys fs.FS) []string {
+	out := []string{}
+	b, err := fs.ReadFile(fsys, "geometry.json")
+	if err != nil {
+		return out
+	}
+	var raw map[string]any
+	if json.Unmarshal(utils.JsonCompatBytes(b), &raw) != nil {
+		return out
+	}
+	for k, v := range raw {
+		if strings.HasPrefix(k, "geometry.") {
+			out = append(out, k)
+			continue
+		}
+		if k != "minecraft:geometry" {
+			continue
+		}
+		list, _ := v.([]any)
+		for _, g := range list {
+			gm, _ := g.(map[string]any)
+			desc, _ := gm["description"].(map[string]any)
+			if id, ok := desc["identifier"].(string); ok && id != "" {
+				out = append(out, id)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func ReadSkinPackDir(dir string) types.SkinPackInfo {
+	root := findManifestDir(dir)
+	if root == "" {
+		root = dir
+	}
+	if !utils.IsDir(root) {
+		return types.SkinPackInfo{Path: dir, Error: "ERR_NOT_FOUND"}
+	}
+	return ReadSkinPackFS(os.DirFS(root), root)
+}
+
+func ReadSkinPackArchiveFile(archivePath string) types.SkinPackInfo {
+	var info types.SkinPackInfo
+	code := WithArchiveFile(archivePath, func(r io.ReaderAt, size int64) string {
+		zr, err := safezip.NewReader(r, size)
+		if err != nil {
+			return safezip.ErrorCode(err)
+		}
+		root := "."
+		for _, f := range zr.File {
+			name := normalizeZipEntryName(f.Name)
+			if strings.EqualFold(path.Base(name), "skins.json") {
+				root = path.Dir(name)
+				break
+			}
+		}
+		sub, err := fs.Sub(zr, root)
+		if err != nil {
+			return "ERR_INVALID_PACKAGE"
+		}
+		info = ReadSkinPackFS(sub, archivePath)
+		return ""
+	})
+	if code != "" {
+		return types.SkinPackInfo{Path: archivePath, Error: code}
+	}
+	return info
+}
+
+func skinIdentifier(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if upper {
+				b.WriteRune(unicode.ToUpper(r))
+			} else {
+				b.WriteRune(r)
+			}
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	return b.String()
+}
+
+func skinDisplayName(stem string) string {
+	words := strings.FieldsFunc(stem, func(r rune) bool { return r == '_' || r == '-' || r == ' ' || r == '.' })
+	for i, w := range words {
+		rs := []rune(w)
+		rs[0] = unicode.ToUpper(rs[0])
+		words[i] = string(rs)
+	}
+	return strings.Join(words, " ")
+}
+
+func newPackUUID() string {
+	b := make([]byte, 16)
+	_, _ = crand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func writeLangFile(p string, keys []string, entries map[string]string) error {
+	var buf bytes.Buffer
+	for _, k := range keys {
+		v := strings.NewReplacer("\r", " ", "\n", " ").Replace(entries[k])
+		buf.WriteString(k + "=" + v + "\n")
+	}
+	return os.WriteFile(p, buf.Bytes(), 0644)
+}
+
+func BuildSkinPack(req types.SkinPackBuildRequest) types.SkinPackBuildResult {
+	res := types.SkinPackBuildResult{Skipped: []string{}}
+	src := strings.TrimSpace(req.SourceDir)
+	out := strings.TrimSpace(req.OutputPath)
+	name := strings.TrimSpace(req.Name)
+	if !utils.IsDir(src) {
+		res.Error = "ERR_INVALID_PATH"
+		return res
+	}
+	if out == "" || name == "" {
+		res.Error = "ERR_INVALID_NAME"
+		return res
+	}
+	if !strings.HasSuffix(strings.ToLower(out), ".mcpack") {
+		out += ".mcpack"
+	}
+	serialize := skinIdentifier(name)
+	if serialize == "" {
+		serialize = "SkinPack"
+	}
+	slimSuffix := req.SlimSuffix
+	if slimSuffix == "" {
+		slimSuffix = "_slim"
+	}
+	version := req.Version
+	if len(version) != 3 {
+		version = []int{1, 0, 0}
+	}
+	id := strings.TrimSpace(req.UUID)
+	if id == "" {
+		id = newPackUUID()
+	}
+
+	ents, err := os.ReadDir(src)
+	if err != nil {
+		res.Error = "ERR_READ_DIR"
+		return res
+	}
+	stage, err := os.MkdirTemp("", "levilauncher-skinpack-*")
+	if err != nil {
+		res.Error = "ERR_CREATE_TARGET_DIR"
+		return res
+	}
+	defer os.RemoveAll(stage)
+
+	type builtSkin struct {
+		stem     string
+		locName  string
+		geometry string
+		texture  string
+	}
+	var skins []builtSkin
+	usedNames := map[string]struct{}{}
+	for _, e := range ents {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".png") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(src, e.Name()))
+		if err != nil {
+			res.Skipped = append(res.Skipped, e.Name()+": ERR_READ_FILE")
+			continue
+		}
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(b))
+		if err != nil || format != "png" {
+			res.Skipped = append(res.Skipped, e.Name()+": ERR_SKIN_INVALID_PNG")
+			continue
+		}
+		if !((cfg.Width == 64 && cfg.Height == 64) || (cfg.Width == 128 && cfg.Height == 128)) {
+			res.Skipped = append(res.Skipped, fmt.Sprintf("%s: ERR_SKIN_SIZE %dx%d", e.Name(), cfg.Width, cfg.Height))
+			continue
+		}
+		stem := strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+		geometry := SkinGeometryCustom
+		if strings.HasSuffix(strings.ToLower(stem), strings.ToLower(slimSuffix)) {
+			stem = stem[:len(stem)-len(slimSuffix)]
+			geometry = SkinGeometryCustomSlim
+		}
+		locName := uniqueExportName(skinIdentifier(stem), usedNames)
+		if locName == "" {
+			locName = uniqueExportName("Skin", usedNames)
+		}
+		texture := locName + ".png"
+		if err := os.WriteFile(filepath.Join(stage, texture), b, 0644); err != nil {
+			res.Error = "ERR_WRITE_FILE"
+			return res
+		}
+		skins = append(skins, builtSkin{stem: stem, locName: locName, geometry: geometry, texture: texture})
+	}
+	if len(skins) == 0 {
+		res.Error = "ERR_NO_SKINS"
+		return res
+	}
+	sort.Slice(skins, func(i, j int) bool { return skins[i].locName < skins[j].locName })
+
+	manifest := map[string]any{
+		"format_version": 1,
+		"header": map[string]any{
+			"name":        "pack.name",
+			"description": "pack.description",
+			"uuid":        id,
+			"version":     version,
+		},
+		"modules": []any{map[string]any{
+			"type":    "skin_pack",
+			"uuid":    newPackUUID(),
+			"version": version,
+		}},
+	}
+	skinList := make([]map[string]any, 0, len(skins))
+	for _, s := range skins {
+		skinList = append(skinList, map[string]any{
+			"localization_name": s.locName,
+			"geometry":          s.geometry,
+			"texture":           s.texture,
+			"type":              "free",
+		})
+	}
+	skinsDoc := map[string]any{
+		"skins":             skinList,
+		"serialize_name":    serialize,
+		"localization_name": serialize,
+	}
+	for file, doc := range map[string]any{"manifest.json": manifest, "skins.json": skinsDoc} {
+		b, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			res.Error = "ERR_WRITE_FILE"
+			return res
+		}
+		if err := os.WriteFile(filepath.Join(stage, file), b, 0644); err != nil {
+			res.Error = "ERR_WRITE_FILE"
+			return res
+		}
+	}
+
+	languages := []string{lang.DefaultLanguage}
+	for code := range req.Names {
+		languages = append(languages, lang.NormalizeCode(code))
+	}
+	for code := range req.SkinNames {
+		languages = append(languages, lang.NormalizeCode(code))
+	}
+	sort.Strings(languages[1:])
+	var langList []string
+	seenLang := map[string]struct{}{}
+	for _, code := range languages {
+		if _, ok := seenLang[code]; ok || code == "" {
+			continue
+		}
+		seenLang[code] = struct{}{}
+		langList = append(langList, code)
+	}
+	if err := os.MkdirAll(filepath.Join(stage, "texts"), 0755); err != nil {
+		res.Error = "ERR_CREATE_TARGET_DIR"
+		return res
+	}
+	lookup := func(m map[string]string, code string) (string, bool) {
+		for k, v := range m {
+			if lang.NormalizeCode(k) == code && strings.TrimSpace(v) != "" {
+				return strings.TrimSpace(v), true
+			}
+		}
+		return "", false
+	}
+	for _, code := range langList {
+		packName := name
+		if v, ok := lookup(req.Names, code); ok {
+			packName = v
+		}
+		entries := map[string]string{
+			"pack.name":             packName,
+			"pack.description":      strings.TrimSpace(req.Description),
+			"skinpack." + serialize: packName,
+		}
+		keys := []string{"pack.name", "pack.description", "skinpack." + serialize}
+		var perLang map[string]string
+		for k, m := range req.SkinNames {
+			if lang.NormalizeCode(k) == code {
+				perLang = m
+			}
+		}
+		for _, s := range skins {
+			key := "skin." + serialize + "." + s.locName
+			display := skinDisplayName(s.stem)
+			if v, ok := perLang[s.stem]; ok && strings.TrimSpace(v) != "" {
+				display = strings.TrimSpace(v)
+			}
+			entries[key] = display
+			keys = append(keys, key)
+		}
+		if err := writeLangFile(filepath.Join(stage, "texts", code+".lang"), keys, entries); err != nil {
+			res.Error = "ERR_WRITE_FILE"
+			return res
+		}
+	}
+	lb, _ := json.MarshalIndent(langList, "", "  ")
+	if err := os.WriteFile(filepath.Join(stage, "texts", "languages.json"), lb, 0644); err != nil {
+		res.Error = "ERR_WRITE_FILE"
+		return res
+	}
+
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		res.Error = "ERR_CREATE_TARGET_DIR"
+		return res
+	}
+	if err := ExportMcpack(stage, out); err != nil {
+		res.Error = "ERR_WRITE_FILE"
+		return res
+	}
+	res.Path = out
+	res.UUID = id
+	res.Skins = len(skins)
+	return res
+}
@@ -0,0 +1,104 @@
+package content
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/safezip"
+)
+
+var ErrLevelDatMissing = errors.New("level.dat not found in archive")
+
+type WorldSummary struct {
+	LevelName             string
+	Seed                  string
+	GameMode              int32
+	LastPlayed            int64
+	LastOpenedWithVersion string
+}
+
+func ReadWorldSummary(worldDir string) (WorldSummary, error) {
+	root, _, err := DecodeLevelDat(worldDir)
+	if err != nil {
+		return WorldSummary{}, err
+	}
+	return summarizeLevelDat(root), nil
+}
+
+func ReadMcworldSummary(archive []byte) (WorldSummary, error) {
+	return ReadMcworldSummaryReader(bytes.NewReader(archive), int64(len(archive)))
+}
+
+func ReadMcworldSummaryReader(r io.ReaderAt, size int64) (WorldSummary, error) {
+	zr, err := safezip.NewReader(r, size)
+	if err != nil {
+		return WorldSummary{}, err
+	}
+	var best *zip.File
+	bestDepth := 0
+	for _, f := range zr.File {
+		name := normalizeZipEntryName(f.Name)
+		if !strings.EqualFold(path.Base(name), "level.dat") {
+			continue
+		}
+		depth := strings.Count(name, "/")
+		if best == nil || depth < bestDepth {
+			best, bestDepth = f, depth
+		}
+	}
+	if best == nil {
+		return WorldSummary{}, ErrLevelDatMissing
+	}
+	rc, err := best.Open()
+	if err != nil {
+		return WorldSummary{}, err
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(io.LimitReader(rc, 64<<20))
+	if err != nil {
+		return WorldSummary{}, err
+	}
+	root := decodeLevelDatBytes(b)
+	if root == nil {
+		return WorldSummary{}, errors.New("invalid level.dat")
+	}
+	return summarizeLevelDat(root), nil
+}
+
+func summarizeLevelDat(root map[string]any) WorldSummary {
+	var s WorldSummary
+	data := levelDatDataRoot(root)
+	if v, ok := data["LevelName"].(string); ok {
+		s.LevelName = strings.TrimSpace(v)
+	}
+	if v, ok := data["RandomSeed"]; ok {
+		s.Seed = strconv.FormatInt(toInt64(v), 10)
+	}
+	s.GameMode = int32(toInt64(data["GameType"]))
+	s.LastPlayed = toInt64(data["LastPlayed"])
+	s.LastOpenedWithVersion = formatLevelDatVersion(data["lastOpenedWithVersion"])
+	return s
+}
+
+func formatLevelDatVersion(v any) string {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return ""
+	}
+	n := rv.Len()
+	if n > 4 {
+		n = 4
+	}
+	parts := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		parts = append(parts, fmt.Sprintf("%d", toInt64(rv.Index(i).Interface())))
+	}
+	return strings.Join(parts, ".")
+}
@@ -1,7 +1,6 @@
 package gdk
 
 import (
-	"archive/zip"
 	"context"
 	"fmt"
 	"io"
@@ -15,6 +14,7 @@ import (
 	"github.com/wailsapp/wails/v3/pkg/application"
 
 	"github.com/liteldev/LeviLauncher/internal/downloader"
+	"github.com/liteldev/LeviLauncher/internal/safezip"
 	"github.com/liteldev/LeviLauncher/internal/utils"
 )
 
@@ -114,40 +114,20 @@ func InstallFromZip(ctx context.Context, zipPath string) string {
 }
 
 func unzip(src string, dest string) error {
-	r, err := zip.OpenReader(src)
+	f, err := os.Open(src)
 	if err != nil {
 		return err
 	}
-	defer r.Close()
-	for _, f := range r.File {
-		fp := filepath.Join(dest, f.Name)
-		if f.FileInfo().IsDir() {
-			if err := os.MkdirAll(fp, 0o755); err != nil {
-				return err
-			}
-			continue
-		}
-		if err := os.MkdirAll(filepath.Dir(fp), 0o755); err != nil {
-			return err
-		}
-		rc, err := f.Open()
-		if err != nil {
-			return err
-		}
-		out, err := os.OpenFile(fp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode())
-		if err != nil {
-			_ = rc.Close()
-			return err
-		}
-		if _, err = io.Copy(out, rc); err != nil {
-			_ = out.Close()
-			_ = rc.Close()
-			return err
-		}
-		_ = out.Close()
-		_ = rc.Close()
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	zr, err := safezip.NewReader(f, fi.Size())
+	if err != nil {
+		return err
 	}
-	return nil
+	return safezip.ExtractDir(zr, "", dest)
 }
 
 func deriveFilename(raw string) string {
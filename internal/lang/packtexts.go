@@ -0,0 +1,239 @@
+package lang
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	json "github.com/goccy/go-json"
+	"github.com/liteldev/LeviLauncher/internal/config"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const DefaultLanguage = "en_US"
+
+type PackTexts struct {
+	Declared  []string
+	Languages []string
+	Entries   map[string]map[string]string
+}
+
+var (
+	currentMu     sync.RWMutex
+	currentLang   string
+	currentLoaded bool
+)
+
+func NormalizeCode(code string) string {
+	c := strings.TrimSpace(code)
+	c = strings.TrimSuffix(strings.TrimSuffix(c, ".lang"), ".LANG")
+	c = strings.ReplaceAll(c, "-", "_")
+	if c == "" {
+		return ""
+	}
+	parts := strings.SplitN(c, "_", 2)
+	if len(parts) == 1 {
+		return strings.ToLower(parts[0])
+	}
+	return strings.ToLower(parts[0]) + "_" + strings.ToUpper(parts[1])
+}
+
+func Current() string {
+	currentMu.RLock()
+	if currentLoaded {
+		l := currentLang
+		currentMu.RUnlock()
+		return l
+	}
+	currentMu.RUnlock()
+	l := NormalizeCode(config.GetLanguage())
+	if l == "" {
+		l = DefaultLanguage
+	}
+	currentMu.Lock()
+	currentLang = l
+	currentLoaded = true
+	currentMu.Unlock()
+	return l
+}
+
+func SetCurrent(code string) {
+	l := NormalizeCode(code)
+	if l == "" {
+		l = DefaultLanguage
+	}
+	currentMu.Lock()
+	currentLang = l
+	currentLoaded = true
+	currentMu.Unlock()
+}
+
+func ParseLangFile(b []byte) map[string]string {
+	b = bytes.TrimPrefix(b, []byte{0xEF, 0xBB, 0xBF})
+	m := make(map[string]string, 16)
+	for _, ln := range strings.Split(string(b), "\n") {
+		l := strings.TrimRight(ln, "\r")
+		if t := strings.TrimSpace(l); t == "" || strings.HasPrefix(t, "#") {
+			continue
+		}
+		if idx := strings.Index(l, "##"); idx >= 0 {
+			l = l[:idx]
+		}
+		if idx := strings.Index(l, "\t#"); idx >= 0 {
+			l = l[:idx]
+		}
+		kv := strings.SplitN(l, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		k := strings.TrimSpace(kv[0])
+		if k == "" {
+			continue
+		}
+		m[k] = strings.TrimSpace(kv[1])
+	}
+	return m
+}
+
+func ParseLanguagesJSON(b []byte) []string {
+	var raw []string
+	if err := json.Unmarshal(utils.JsonCompatBytes(b), &raw); err != nil {
+		return nil
+	}
+	out := make([]string, 0, len(raw))
+	seen := map[string]bool{}
+	for _, v := range raw {
+		c := NormalizeCode(v)
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		out = append(out, c)
+	}
+	return out
+}
+
+func ReadPackTexts(root string) PackTexts {
+	if !utils.IsDir(filepath.Join(root, "texts")) {
+		return PackTexts{Entries: map[string]map[string]string{}}
+	}
+	return ReadPackTextsFS(os.DirFS(root))
+}
+
+func ReadPackTextsFS(fsys fs.FS) PackTexts {
+	t := PackTexts{Entries: map[string]map[string]string{}}
+	if b, err := fs.ReadFile(fsys, "texts/languages.json"); err == nil {
+		t.Declared = ParseLanguagesJSON(b)
+	}
+	ents, err := fs.ReadDir(fsys, "texts")
+	if err != nil {
+		return t
+	}
+	for _, e := range ents {
+		if e.IsDir() || !strings.HasSuffix(strings.ToLower(e.Name()), ".lang") {
+			continue
+		}
+		code := NormalizeCode(e.Name()[:len(e.Name())-len(".lang")])
+		if code == "" {
+			continue
+		}
+		b, err := fs.ReadFile(fsys, "texts/"+e.Name())
+		if err != nil {
+			continue
+		}
+		entries := ParseLangFile(b)
+		if prev, ok := t.Entries[code]; ok {
+			for k, v := range prev {
+				if _, exists := entries[k]; !exists {
+					entries[k] = v
+				}
+			}
+		}
+		t.Entries[code] = entries
+	}
+	seen := map[string]bool{}
+	for _, c := range t.Declared {
+		if _, ok := t.Entries[c]; ok && !seen[c] {
+			seen[c] = true
+			t.Languages = append(t.Languages, c)
+		}
+	}
+	var rest []string
+	for c := range t.Entries {
+		if !seen[c] {
+			rest = append(rest, c)
+		}
+	}
+	sort.Strings(rest)
+	t.Languages = append(t.Languages, rest...)
+	return t
+}
+
+func (t PackTexts) Empty() bool { return len(t.Entries) == 0 }
+
+func (t PackTexts) fallbackChain(language string) []string {
+	var chain []string
+	add := func(c string) {
+		if c == "" {
+			return
+		}
+		if _, ok := t.Entries[c]; !ok {
+			return
+		}
+		for _, x := range chain {
+			if x == c {
+				return
+			}
+		}
+		chain = append(chain, c)
+	}
+	l := NormalizeCode(language)
+	add(l)
+	if i := strings.IndexByte(l, '_'); i > 0 {
+		prefix := l[:i+1]
+		for _, c := range t.Languages {
+			if strings.HasPrefix(c, prefix) {
+				add(c)
+			}
+		}
+	}
+	add(DefaultLanguage)
+	if len(t.Languages) > 0 {
+		add(t.Languages[0])
+	}
+	return chain
+}
+
+func (t PackTexts) Text(key, language string) (string, bool) {
+	for _, c := range t.fallbackChain(language) {
+		if v, ok := t.Entries[c][key]; ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func (t PackTexts) Resolve(language string) map[string]string {
+	chain := t.fallbackChain(language)
+	if len(chain) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(t.Entries[chain[0]]))
+	for i := len(chain) - 1; i >= 0; i-- {
+		for k, v := range t.Entries[chain[i]] {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+func (t PackTexts) Localize(s, language string) string {
+	if v, ok := t.Text(s, language); ok {
+		return v
+	}
+	return s
+}
@@ -0,0 +1,152 @@
+package mcservice
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/versions"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+const (
+	WorldCompatOK        = "ok"
+	WorldCompatUpgrade   = "upgrade"
+	WorldCompatDowngrade = "downgrade"
+	WorldCompatUnknown   = "unknown"
+)
+
+func targetGameVersion(versionName string) string {
+	if strings.TrimSpace(versionName) == "" {
+		return ""
+	}
+	return semanticGameVersion(GetVersionMeta(versionName).GameVersion)
+}
+
+func semanticGameVersion(raw string) string {
+	s := normalizeBedrockGameVersion(raw)
+	if !isFourPartNumericVersion(s) {
+		return s
+	}
+	parts := strings.Split(s, ".")
+	patch, _ := strconv.Atoi(parts[2])
+	build, _ := strconv.Atoi(parts[3])
+	if patch >= 100 && build == 0 {
+		return fmt.Sprintf("%s.%s.%d.%d", parts[0], parts[1], patch/100, patch%100)
+	}
+	return s
+}
+
+func worldCompatStatus(worldVersion string, targetVersion string) string {
+	if strings.TrimSpace(worldVersion) == "" || strings.TrimSpace(targetVersion) == "" {
+		return WorldCompatUnknown
+	}
+	switch versions.CompareGameVersion(worldVersion, targetVersion) {
+	case 1:
+		return WorldCompatDowngrade
+	case -1:
+		return WorldCompatUpgrade
+	}
+	return WorldCompatOK
+}
+
+func CheckWorldCompatibility(worldDir string, versionName string) types.WorldCompatibility {
+	res := types.WorldCompatibility{WorldDir: worldDir, TargetVersion: targetGameVersion(versionName), Status: WorldCompatUnknown}
+	s, err := content.ReadWorldSummary(worldDir)
+	if err != nil {
+		return res
+	}
+	res.LevelName = s.LevelName
+	if nm := GetWorldLevelName(worldDir); nm != "" {
+		res.LevelName = nm
+	}
+	res.WorldVersion = s.LastOpenedWithVersion
+	res.Status = worldCompatStatus(res.WorldVersion, res.TargetVersion)
+	return res
+}
+
+func ListWorldCompatibility(versionName string) []types.WorldCompatibility {
+	out := []types.WorldCompatibility{}
+	roots := GetContentRoots(versionName)
+	for _, w := range listWorldDirs(roots.UsersRoot) {
+		c := CheckWorldCompatibility(w.Dir, versionName)
+		c.Player = w.Player
+		out = append(out, c)
+	}
+	return out
+}
+
+func ListDowngradeRiskWorlds(versionName string) []types.WorldCompatibility {
+	out := []types.WorldCompatibility{}
+	for _, c := range ListWorldCompatibility(versionName) {
+		if c.Status == WorldCompatDowngrade {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+var (
+	pendingLaunchMu sync.Mutex
+	pendingLaunch   types.PendingLaunch
+)
+
+func BackupDowngradeRiskWorlds(versionName string) []types.WorldBackupResult {
+	out := []types.WorldBackupResult{}
+	for _, c := range ListDowngradeRiskWorlds(versionName) {
+		r := types.WorldBackupResult{WorldDir: c.WorldDir, LevelName: c.LevelName}
+		if r.Path = BackupWorldWithVersion(c.WorldDir, versionName); r.Path == "" {
+			r.Error = "ERR_BACKUP_WORLD"
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func SetPendingLaunch(p types.PendingLaunch) {
+	pendingLaunchMu.Lock()
+	pendingLaunch = p
+	pendingLaunchMu.Unlock()
+	if app := application.Get(); app != nil {
+		app.Event.Emit(EventLaunchPending, p.VersionName)
+	}
+}
+
+func TakePendingLaunch() types.PendingLaunch {
+	pendingLaunchMu.Lock()
+	defer pendingLaunchMu.Unlock()
+	p := pendingLaunch
+	pendingLaunch = types.PendingLaunch{}
+	return p
+}
+
+func CheckMcworldCompatibility(versionName string, data []byte) types.WorldCompatibility {
+	res := types.WorldCompatibility{TargetVersion: targetGameVersion(versionName), Status: WorldCompatUnknown}
+	s, err := content.ReadMcworldSummary(data)
+	if err != nil {
+		return res
+	}
+	res.LevelName = s.LevelName
+	res.WorldVersion = s.LastOpenedWithVersion
+	res.Status = worldCompatStatus(res.WorldVersion, res.TargetVersion)
+	return res
+}
+
+func CheckMcworldCompatibilityPath(versionName string, path string) types.WorldCompatibility {
+	res := types.WorldCompatibility{TargetVersion: targetGameVersion(versionName), Status: WorldCompatUnknown}
+	content.WithArchiveFile(path, func(r io.ReaderAt, size int64) string {
+		s, err := content.ReadMcworldSummaryReader(r, size)
+		if err != nil {
+			return "ERR_OPEN_ZIP"
+		}
+		res.LevelName = s.LevelName
+		res.WorldVersion = s.LastOpenedWithVersion
+		res.Status = worldCompatStatus(res.WorldVersion, res.TargetVersion)
+		return ""
+	})
+	return res
+}
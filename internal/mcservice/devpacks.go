@@ -0,0 +1,645 @@
+package mcservice
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/packages"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+	"github.com/wailsapp/wails/v3/pkg/application"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	DevPackModeLink = "link"
+	DevPackModeSync = "sync"
+)
+
+var (
+	devPacksMu     sync.Mutex
+	devWatchersMu  sync.Mutex
+	devPackSyncMu  sync.Mutex
+	devPackWatches = map[string]*devPackWatcher{}
+)
+
+type devPacksFile struct {
+	Packs []types.DevPack `json:"packs"`
+}
+
+type devPackWatcher struct {
+	id        string
+	stop      chan struct{}
+	kick      chan struct{}
+	stopEvent windows.Handle
+	wg        sync.WaitGroup
+}
+
+func devPacksPath() string {
+	return filepath.Join(utils.BaseRoot(), "devpacks.json")
+}
+
+func loadDevPacks() []types.DevPack {
+	b, err := os.ReadFile(devPacksPath())
+	if err != nil {
+		return []types.DevPack{}
+	}
+	var f devPacksFile
+	if json.Unmarshal(b, &f) != nil || f.Packs == nil {
+		return []types.DevPack{}
+	}
+	return f.Packs
+}
+
+func saveDevPacks(packs []types.DevPack) error {
+	p := devPacksPath()
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(devPacksFile{Packs: packs}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(p, b, 0644)
+}
+
+func updateDevPack(id string, fn func(dp *types.DevPack)) (types.DevPack, bool) {
+	devPacksMu.Lock()
+	defer devPacksMu.Unlock()
+	packs := loadDevPacks()
+	for i := range packs {
+		if packs[i].ID == id {
+			fn(&packs[i])
+			_ = saveDevPacks(packs)
+			return packs[i], true
+		}
+	}
+	return types.DevPack{}, false
+}
+
+func findDevPack(id string) (types.DevPack, bool) {
+	devPacksMu.Lock()
+	defer devPacksMu.Unlock()
+	for _, dp := range loadDevPacks() {
+		if dp.ID == id {
+			return dp, true
+		}
+	}
+	return types.DevPack{}, false
+}
+
+func devPackParent(name string, kind string) string {
+	roots := GetContentRoots(name)
+	if strings.TrimSpace(roots.UsersRoot) == "" {
+		return ""
+	}
+	shared := filepath.Join(roots.UsersRoot, "Shared", "games", "com.mojang")
+	switch kind {
+	case LibraryKindResource:
+		return filepath.Join(shared, "development_resource_packs")
+	case LibraryKindBehavior:
+		return filepath.Join(shared, "development_behavior_packs")
+	}
+	return ""
+}
+
+func devPackID(name string, source string, kind string) string {
+	sum := sha1.Sum([]byte(strings.ToLower(strings.TrimSpace(name)) + "|" + strings.ToLower(filepath.Clean(source)) + "|" + kind))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func detectDevPackKind(source string) string {
+	raw, err := os.ReadFile(filepath.Join(source, "manifest.json"))
+	if err != nil {
+		return ""
+	}
+	m, err := packages.ParseManifestData(raw, packages.PackTypeInvalid)
+	if err != nil {
+		return ""
+	}
+	switch m.PackType {
+	case packages.PackTypeResources:
+		return LibraryKindResource
+	case packages.PackTypeBehavior:
+		return LibraryKindBehavior
+	}
+	return ""
+}
+
+func isLinkPath(p string) bool {
+	fi, err := os.Lstat(p)
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&(os.ModeSymlink|os.ModeIrregular) != 0
+}
+
+func junctionReparseData(source string) []byte {
+	sub := utf16.Encode([]rune(`\??\` + source))
+	printName := utf16.Encode([]rune(source))
+	pathBytes := (len(sub) + 1 + len(printName) + 1) * 2
+	buf := make([]byte, 16+pathBytes)
+	binary.LittleEndian.PutUint32(buf[0:], windows.IO_REPARSE_TAG_MOUNT_POINT)
+	binary.LittleEndian.PutUint16(buf[4:], uint16(8+pathBytes))
+	binary.LittleEndian.PutUint16(buf[8:], 0)
+	binary.LittleEndian.PutUint16(buf[10:], uint16(len(sub)*2))
+	binary.LittleEndian.PutUint16(buf[12:], uint16((len(sub)+1)*2))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(len(printName)*2))
+	off := 16
+	for _, c := range sub {
+		binary.LittleEndian.PutUint16(buf[off:], c)
+		off += 2
+	}
+	off += 2
+	for _, c := range printName {
+		binary.LittleEndian.PutUint16(buf[off:], c)
+		off += 2
+	}
+	return buf
+}
+
+func createJunction(target string, source string) error {
+	src, err := filepath.Abs(source)
+	if err != nil {
+		return err
+	}
+	src = strings.TrimPrefix(filepath.Clean(src), `\\?\`)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if err := os.Mkdir(target, 0755); err != nil {
+		return err
+	}
+	p, err := windows.UTF16PtrFromString(target)
+	if err != nil {
+		_ = os.Remove(target)
+		return err
+	}
+	h, err := windows.CreateFile(p, windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING,
+		windows.FILE_FLAG_BACKUP_SEMANTICS|windows.FILE_FLAG_OPEN_REPARSE_POINT, 0)
+	if err != nil {
+		_ = os.Remove(target)
+		return err
+	}
+	data := junctionReparseData(src)
+	var n uint32
+	err = windows.DeviceIoControl(h, windows.FSCTL_SET_REPARSE_POINT, &data[0], uint32(len(data)), nil, 0, &n, nil)
+	_ = windows.CloseHandle(h)
+	if err != nil {
+		_ = os.Remove(target)
+		return err
+	}
+	return nil
+}
+
+func skipDevPackEntry(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.EqualFold(name, "node_modules")
+}
+
+func bumpManifestVersion(raw []byte, revision int) ([]byte, string, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(utils.JsonCompatBytes(raw), &doc); err != nil {
+		return nil, "", err
+	}
+	header, _ := doc["header"].(map[string]any)
+	if header == nil {
+		return raw, "", nil
+	}
+	version := ""
+	switch v := header["version"].(type) {
+	case []any:
+		parts := make([]int, 3)
+		for i := 0; i < len(v) && i < 3; i++ {
+			if f, ok := v[i].(float64); ok {
+				parts[i] = int(f)
+			}
+		}
+		parts[2] += revision
+		header["version"] = []int{parts[0], parts[1], parts[2]}
+		version = formatVersionParts(parts)
+	case string:
+		core, pre, hasPre := strings.Cut(v, "-")
+		segs := strings.Split(core, ".")
+		for len(segs) < 3 {
+			segs = append(segs, "0")
+		}
+		patch, _ := strconv.Atoi(segs[2])
+		segs[2] = strconv.Itoa(patch + revision)
+		version = strings.Join(segs, ".")
+		if hasPre {
+			version += "-" + pre
+		}
+		header["version"] = version
+	default:
+		return raw, "", nil
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	return b, version, err
+}
+
+func formatVersionParts(parts []int) string {
+	s := make([]string, len(parts))
+	for i, p := range parts {
+		s[i] = strconv.Itoa(p)
+	}
+	return strings.Join(s, ".")
+}
+
+func mirrorDevPack(dp types.DevPack) (int, int, bool, error) {
+	copied, removed := 0, 0
+	manifestChanged := false
+	keep := map[string]struct{}{}
+	err := filepath.Walk(dp.Source, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dp.Source, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return os.MkdirAll(dp.Target, 0755)
+		}
+		if skipDevPackEntry(info.Name()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		keep[strings.ToLower(rel)] = struct{}{}
+		target := filepath.Join(dp.Target, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		isManifest := dp.BumpVersion && strings.EqualFold(rel, "manifest.json")
+		if ti, err := os.Stat(target); err == nil && ti.ModTime().Equal(info.ModTime()) && (isManifest || ti.Size() == info.Size()) {
+			return nil
+		}
+		if isManifest {
+			manifestChanged = true
+			return nil
+		}
+		if err := copySyncFile(p, target); err != nil {
+			return err
+		}
+		copied++
+		return os.Chtimes(target, info.ModTime(), info.ModTime())
+	})
+	if err != nil {
+		return copied, removed, manifestChanged, err
+	}
+	var extra []string
+	_ = filepath.Walk(dp.Target, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, rerr := filepath.Rel(dp.Target, p)
+		if rerr != nil || rel == "." {
+			return nil
+		}
+		if _, ok := keep[strings.ToLower(rel)]; !ok {
+			extra = append(extra, p)
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	for _, p := range extra {
+		if err := os.RemoveAll(p); err == nil {
+			removed++
+		}
+	}
+	return copied, removed, manifestChanged, nil
+}
+
+func syncDevPack(dp types.DevPack) types.DevPackSyncResult {
+	devPackSyncMu.Lock()
+	defer devPackSyncMu.Unlock()
+	res := types.DevPackSyncResult{ID: dp.ID}
+	if !utils.IsDir(dp.Source) {
+		res.Error = "ERR_INHERIT_SOURCE_NOT_FOUND"
+		return res
+	}
+	if isLinkPath(dp.Target) {
+		res.Error = "ERR_TARGET_IS_LINK"
+		return res
+	}
+	copied, removed, manifestChanged, err := mirrorDevPack(dp)
+	res.Copied, res.Removed = copied, removed
+	if err != nil {
+		res.Error = "ERR_WRITE_FILE"
+		return res
+	}
+	revision := dp.Revision
+	if dp.BumpVersion {
+		targetManifest := filepath.Join(dp.Target, "manifest.json")
+		if copied+removed > 0 || manifestChanged || !utils.FileExists(targetManifest) {
+			if utils.FileExists(targetManifest) {
+				revision++
+			}
+			src := filepath.Join(dp.Source, "manifest.json")
+			raw, err := os.ReadFile(src)
+			if err != nil {
+				res.Error = "ERR_MANIFEST_NOT_FOUND"
+				return res
+			}
+			b, version, err := bumpManifestVersion(raw, revision)
+			if err != nil {
+				res.Error = "ERR_MANIFEST_INVALID"
+				return res
+			}
+			if err := utils.WriteFileAtomic(targetManifest, b, 0644); err != nil {
+				res.Error = "ERR_WRITE_FILE"
+				return res
+			}
+			if fi, err := os.Stat(src); err == nil {
+				_ = os.Chtimes(targetManifest, fi.ModTime(), fi.ModTime())
+			}
+			res.Version = version
+			res.Copied++
+		}
+	}
+	updateDevPack(dp.ID, func(p *types.DevPack) {
+		p.Revision = revision
+		p.LastSync = time.Now().Unix()
+		p.LastError = res.Error
+	})
+	return res
+}
+
+func ListDevPacks() []types.DevPack {
+	devPacksMu.Lock()
+	packs := loadDevPacks()
+	devPacksMu.Unlock()
+	sort.SliceStable(packs, func(i, j int) bool {
+		if packs[i].VersionName != packs[j].VersionName {
+			return packs[i].VersionName < packs[j].VersionName
+		}
+		return strings.ToLower(packs[i].Folder) < strings.ToLower(packs[j].Folder)
+	})
+	return packs
+}
+
+func RegisterDevPack(name string, source string, kind string, mode string, bumpVersion bool, watch bool) types.DevPack {
+	dp := types.DevPack{VersionName: strings.TrimSpace(name), Source: strings.TrimSpace(source), BumpVersion: bumpVersion, Watch: watch}
+	if dp.VersionName == "" {
+		dp.Error = "ERR_INVALID_NAME"
+		return dp
+	}
+	if dp.Source == "" || !utils.IsDir(dp.Source) {
+		dp.Error = "ERR_INVALID_PATH"
+		return dp
+	}
+	if abs, err := filepath.Abs(dp.Source); err == nil {
+		dp.Source = abs
+	}
+	if !utils.FileExists(filepath.Join(dp.Source, "manifest.json")) {
+		dp.Error = "ERR_MANIFEST_NOT_FOUND"
+		return dp
+	}
+	dp.Kind = strings.ToLower(strings.TrimSpace(kind))
+	if dp.Kind == "" {
+		dp.Kind = detectDevPackKind(dp.Source)
+	}
+	if dp.Kind != LibraryKindResource && dp.Kind != LibraryKindBehavior {
+		dp.Error = "ERR_INVALID_PACKAGE"
+		return dp
+	}
+	dp.Mode = strings.ToLower(strings.TrimSpace(mode))
+	if dp.Mode == "" {
+		dp.Mode = DevPackModeSync
+	}
+	if dp.Mode != DevPackModeLink && dp.Mode != DevPackModeSync {
+		dp.Error = "ERR_INVALID_MODE"
+		return dp
+	}
+	if dp.Mode == DevPackModeLink && dp.BumpVersion {
+		dp.Error = "ERR_DEVPACK_BUMP_REQUIRES_SYNC"
+		return dp
+	}
+	parent := devPackParent(dp.VersionName, dp.Kind)
+	if parent == "" {
+		dp.Error = "ERR_ACCESS_VERSIONS_DIR"
+		return dp
+	}
+	if utils.IsPathWithin(dp.Source, parent) || utils.IsPathWithin(parent, dp.Source) {
+		dp.Error = "ERR_INVALID_PATH"
+		return dp
+	}
+	dp.ID = devPackID(dp.VersionName, dp.Source, dp.Kind)
+	dp.Folder = utils.SanitizeFilename(filepath.Base(dp.Source))
+	if dp.Folder == "" {
+		dp.Folder = dp.ID
+	}
+	dp.Target = filepath.Join(parent, dp.Folder)
+
+	devPacksMu.Lock()
+	packs := loadDevPacks()
+	for _, p := range packs {
+		if p.ID == dp.ID {
+			devPacksMu.Unlock()
+			dp.Error = "ERR_DEVPACK_EXISTS"
+			return dp
+		}
+	}
+	if _, err := os.Lstat(dp.Target); err == nil {
+		devPacksMu.Unlock()
+		dp.Error = "ERR_TARGET_EXISTS"
+		return dp
+	}
+	packs = append(packs, dp)
+	if err := saveDevPacks(packs); err != nil {
+		devPacksMu.Unlock()
+		dp.Error = "ERR_WRITE_FILE"
+		return dp
+	}
+	devPacksMu.Unlock()
+
+	if dp.Mode == DevPackModeLink {
+		if err := createJunction(dp.Target, dp.Source); err != nil {
+			_ = removeDevPackEntry(dp.ID)
+			dp.Error = "ERR_DEVPACK_LINK_FAILED"
+			return dp
+		}
+		dp, _ = updateDevPack(dp.ID, func(p *types.DevPack) {
+			p.LastSync = time.Now().Unix()
+			p.LastError = ""
+		})
+		return dp
+	}
+	syncDevPack(dp)
+	if dp.Watch {
+		startDevPackWatcher(dp)
+	}
+	dp, _ = findDevPack(dp.ID)
+	return dp
+}
+
+func UnregisterDevPack(id string, removeTarget bool) string {
+	dp, ok := findDevPack(strings.TrimSpace(id))
+	if !ok {
+		return "ERR_NOT_FOUND"
+	}
+	stopDevPackWatcher(dp.ID)
+	if removeTarget && dp.Target != "" {
+		parent := devPackParent(dp.VersionName, dp.Kind)
+		if parent == "" || !utils.IsPathWithin(parent, dp.Target) {
+			return "ERR_INVALID_PATH"
+		}
+		var err error
+		if isLinkPath(dp.Target) {
+			err = os.Remove(dp.Target)
+		} else if dp.Mode == DevPackModeSync {
+			err = os.RemoveAll(dp.Target)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return "ERR_WRITE_FILE"
+		}
+	}
+	if err := removeDevPackEntry(dp.ID); err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
+
+func removeDevPackEntry(id string) error {
+	devPacksMu.Lock()
+	defer devPacksMu.Unlock()
+	packs := loadDevPacks()
+	kept := packs[:0]
+	for _, p := range packs {
+		if p.ID != id {
+			kept = append(kept, p)
+		}
+	}
+	return saveDevPacks(kept)
+}
+
+func SyncDevPack(id string) types.DevPackSyncResult {
+	dp, ok := findDevPack(strings.TrimSpace(id))
+	if !ok {
+		return types.DevPackSyncResult{ID: id, Error: "ERR_NOT_FOUND"}
+	}
+	if dp.Mode != DevPackModeSync {
+		return types.DevPackSyncResult{ID: id}
+	}
+	return syncDevPack(dp)
+}
+
+func SetDevPackWatch(id string, watch bool) string {
+	dp, ok := updateDevPack(strings.TrimSpace(id), func(p *types.DevPack) {
+		p.Watch = watch
+	})
+	if !ok {
+		return "ERR_NOT_FOUND"
+	}
+	if watch && dp.Mode == DevPackModeSync {
+		startDevPackWatcher(dp)
+	} else {
+		stopDevPackWatcher(dp.ID)
+	}
+	return ""
+}
+
+func StartDevPackWatchers() {
+	for _, dp := range ListDevPacks() {
+		if dp.Watch && dp.Mode == DevPackModeSync {
+			syncDevPack(dp)
+			startDevPackWatcher(dp)
+		}
+	}
+}
+
+func startDevPackWatcher(dp types.DevPack) {
+	devWatchersMu.Lock()
+	defer devWatchersMu.Unlock()
+	if _, ok := devPackWatches[dp.ID]; ok {
+		return
+	}
+	stopEvent, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return
+	}
+	w := &devPackWatcher{id: dp.ID, stop: make(chan struct{}), kick: make(chan struct{}, 1), stopEvent: stopEvent}
+	notify := func() {
+		select {
+		case w.kick <- struct{}{}:
+		default:
+		}
+	}
+	if !watchDirectory(dp.Source, stopEvent, &w.wg, notify) {
+		_ = windows.CloseHandle(stopEvent)
+		updateDevPack(dp.ID, func(p *types.DevPack) { p.LastError = "ERR_WATCHER_START" })
+		return
+	}
+	devPackWatches[dp.ID] = w
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		var debounce <-chan time.Time
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-w.kick:
+				if debounce == nil {
+					debounce = time.After(watchDebounce)
+				}
+			case <-debounce:
+				debounce = nil
+				cur, ok := findDevPack(w.id)
+				if !ok {
+					continue
+				}
+				res := syncDevPack(cur)
+				if res.Copied+res.Removed == 0 && res.Error == "" {
+					continue
+				}
+				if app := application.Get(); app != nil {
+					app.Event.Emit(EventDevPackSynced, res)
+				}
+			}
+		}
+	}()
+}
+
+func stopDevPackWatcher(id string) {
+	devWatchersMu.Lock()
+	w, ok := devPackWatches[id]
+	delete(devPackWatches, id)
+	devWatchersMu.Unlock()
+	if !ok {
+		return
+	}
+	close(w.stop)
+	_ = windows.SetEvent(w.stopEvent)
+	w.wg.Wait()
+	_ = windows.CloseHandle(w.stopEvent)
+}
+
+func StopDevPackWatchers() {
+	devWatchersMu.Lock()
+	ids := make([]string, 0, len(devPackWatches))
+	for id := range devPackWatches {
+		ids = append(ids, id)
+	}
+	devWatchersMu.Unlock()
+	for _, id := range ids {
+		stopDevPackWatcher(id)
+	}
+}
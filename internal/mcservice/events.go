@@ -4,4 +4,24 @@ const (
 	EventExtractError    = "extract.error"
 	EventExtractDone     = "extract.done"
 	EventExtractProgress = "extract.progress"
+
+	EventWorldDowngradeRisk = "world.downgrade_risk"
+	EventScriptModuleRisk   = "pack.script_module_risk"
+	EventLaunchPending      = "launch.pending"
+
+	EventContentChanged  = "content.changed"
+	EventPackAdded       = "pack.added"
+	EventPackRemoved     = "pack.removed"
+	EventPackChanged     = "pack.changed"
+	EventWorldAdded      = "world.added"
+	EventWorldRemoved    = "world.removed"
+	EventWorldChanged    = "world.changed"
+	EventSkinPackAdded   = "skin_pack.added"
+	EventSkinPackRemoved = "skin_pack.removed"
+	EventSkinPackChanged = "skin_pack.changed"
+	EventModAdded        = "mod.added"
+	EventModRemoved      = "mod.removed"
+	EventModChanged      = "mod.changed"
+
+	EventDevPackSynced = "devpack.synced"
 )
@@ -0,0 +1,106 @@
+package mcservice
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+func GetWorldExperiments(worldDir string) []types.WorldExperiment {
+	if strings.TrimSpace(worldDir) == "" || !utils.DirExists(worldDir) {
+		return []types.WorldExperiment{}
+	}
+	list, err := content.ReadWorldExperiments(worldDir)
+	if err != nil {
+		return []types.WorldExperiment{}
+	}
+	return list
+}
+
+func SetWorldExperiments(worldDir string, toggles map[string]bool) string {
+	if strings.TrimSpace(worldDir) == "" || !utils.DirExists(worldDir) {
+		return "ERR_INVALID_WORLD_DIR"
+	}
+	if !utils.FileExists(filepath.Join(worldDir, "level.dat")) {
+		return "ERR_LEVELDAT_NOT_FOUND"
+	}
+	if err := content.SetWorldExperiments(worldDir, toggles); err != nil {
+		if errors.Is(err, content.ErrUnknownExperiment) {
+			return "ERR_UNKNOWN_EXPERIMENT"
+		}
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
+
+func GetWorldExperimentReport(worldDir string, versionName string) types.WorldExperimentReport {
+	roots := GetContentRoots(versionName)
+	idx := content.IndexPacksByUuid(filepath.Join(worldDir, "behavior_packs"), roots.BehaviorPacks, developmentBehaviorPacksDir(roots))
+	return worldExperimentReport(worldDir, "", idx)
+}
+
+func ListWorldExperimentReports(versionName string) []types.WorldExperimentReport {
+	out := []types.WorldExperimentReport{}
+	roots := GetContentRoots(versionName)
+	shared := content.IndexPacksByUuid(roots.BehaviorPacks, developmentBehaviorPacksDir(roots))
+	for _, w := range listWorldDirs(roots.UsersRoot) {
+		idx := content.IndexPacksByUuid(filepath.Join(w.Dir, "behavior_packs"))
+		for k, v := range shared {
+			if _, ok := idx[k]; !ok {
+				idx[k] = v
+			}
+		}
+		out = append(out, worldExperimentReport(w.Dir, w.Player, idx))
+	}
+	return out
+}
+
+func developmentBehaviorPacksDir(roots types.ContentRoots) string {
+	if strings.TrimSpace(roots.BehaviorPacks) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(roots.BehaviorPacks), "development_behavior_packs")
+}
+
+func worldExperimentReport(worldDir string, player string, idx map[string]string) types.WorldExperimentReport {
+	rep := types.WorldExperimentReport{
+		WorldDir:  worldDir,
+		LevelName: GetWorldLevelName(worldDir),
+		Player:    player,
+		Enabled:   []string{},
+		Packs:     []types.PackExperimentNeed{},
+	}
+	enabled := map[string]bool{}
+	if list, err := content.ReadWorldExperiments(worldDir); err == nil {
+		for _, e := range list {
+			if e.Enabled {
+				enabled[e.Key] = true
+				rep.Enabled = append(rep.Enabled, e.Key)
+			}
+		}
+	}
+	refs, _ := content.ReadWorldPackRefs(worldDir, "world_behavior_packs.json")
+	for _, ref := range refs {
+		id := strings.ToLower(strings.TrimSpace(ref.PackID))
+		need := types.PackExperimentNeed{UUID: ref.PackID, Required: []string{}, Missing: []string{}}
+		if p, ok := idx[id]; ok {
+			need.Path = p
+			need.Name = content.ReadPackInfoFromDir(p).Name
+			need.Required = content.DetectPackExperiments(p)
+		}
+		for _, r := range need.Required {
+			if !enabled[r] {
+				need.Missing = append(need.Missing, r)
+			}
+		}
+		if len(need.Required) == 0 {
+			continue
+		}
+		rep.Packs = append(rep.Packs, need)
+	}
+	return rep
+}
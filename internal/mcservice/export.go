@@ -0,0 +1,71 @@
+package mcservice
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+func resolveExportPackDirs(refs []types.PackExportRef) ([]string, string) {
+	dirs := make([]string, 0, len(refs))
+	for _, r := range refs {
+		base := strings.TrimSpace(GetContentRoots(r.VersionName).Base)
+		p := strings.TrimSpace(r.Path)
+		if base == "" || p == "" || !utils.IsPathWithin(base, p) {
+			return nil, "ERR_INVALID_PACKAGE"
+		}
+		if !utils.FileExists(filepath.Join(p, "manifest.json")) {
+			return nil, "ERR_MANIFEST_NOT_FOUND"
+		}
+		if code := content.ProtectionErrorCode(content.CheckPackEditable(p)); code != "" {
+			return nil, code
+		}
+		dirs = append(dirs, p)
+	}
+	if len(dirs) == 0 {
+		return nil, "ERR_INVALID_PACKAGE"
+	}
+	return dirs, ""
+}
+
+func ExportPacks(req types.PackExportRequest) types.PackExportResult {
+	res := types.PackExportResult{Files: []string{}}
+	dest := strings.TrimSpace(req.Dest)
+	if dest == "" {
+		res.Error = "ERR_INVALID_PATH"
+		return res
+	}
+	dirs, code := resolveExportPackDirs(req.Packs)
+	if code != "" {
+		res.Error = code
+		return res
+	}
+	switch strings.ToLower(strings.TrimSpace(req.Format)) {
+	case "mcaddon":
+		if !strings.EqualFold(filepath.Ext(dest), ".mcaddon") {
+			dest += ".mcaddon"
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			res.Error = "ERR_CREATE_TARGET_DIR"
+			return res
+		}
+		if err := content.ExportMcaddon(dirs, dest); err != nil {
+			res.Error = "ERR_WRITE_FILE"
+			return res
+		}
+		res.Files = append(res.Files, dest)
+	case "mcpack":
+		files, err := content.ExportMcpacks(dirs, dest)
+		res.Files = append(res.Files, files...)
+		if err != nil {
+			res.Error = "ERR_WRITE_FILE"
+		}
+	default:
+		res.Error = "ERR_INVALID_FORMAT"
+	}
+	return res
+}
@@ -0,0 +1,209 @@
+package mcservice
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/packages"
+	"github.com/liteldev/LeviLauncher/internal/types"
+)
+
+func globalResourcePacksFile(name string, player string) string {
+	users := strings.TrimSpace(GetContentRoots(name).UsersRoot)
+	if users == "" || strings.TrimSpace(player) == "" {
+		return ""
+	}
+	return filepath.Join(users, player, "games", "com.mojang", "minecraftpe", "global_resource_packs.json")
+}
+
+func resourcePacksByUUID(installed []packages.Pack) map[string]packages.Pack {
+	out := map[string]packages.Pack{}
+	for _, p := range installed {
+		if p.Manifest.PackType != packages.PackTypeResources {
+			continue
+		}
+		id := strings.ToLower(strings.TrimSpace(p.Manifest.Identity.UUID))
+		if cur, ok := out[id]; id != "" && (!ok || cur.Manifest.Identity.Version.Compare(p.Manifest.Identity.Version) < 0) {
+			out[id] = p
+		}
+	}
+	return out
+}
+
+func subpackNames(p packages.Pack) []string {
+	names := make([]string, 0, len(p.Manifest.Subpacks))
+	for _, sp := range p.Manifest.Subpacks {
+		names = append(names, sp.FolderName)
+	}
+	return names
+}
+
+func hasSubpack(p packages.Pack, folder string) bool {
+	for _, sp := range p.Manifest.Subpacks {
+		if strings.EqualFold(sp.FolderName, folder) {
+			return sp.Exists
+		}
+	}
+	return false
+}
+
+func GetGlobalResourcePacks(name string, player string, installed []packages.Pack) []types.GlobalResourcePack {
+	out := []types.GlobalResourcePack{}
+	file := globalResourcePacksFile(name, player)
+	if file == "" {
+		return out
+	}
+	refs, err := content.ReadGlobalResourcePacks(file)
+	if err != nil {
+		return out
+	}
+	byID := resourcePacksByUUID(installed)
+	for _, r := range refs {
+		e := types.GlobalResourcePack{PackID: r.PackID, Version: r.Version, Subpack: r.Subpack, Subpacks: []string{}}
+		if p, ok := byID[strings.ToLower(strings.TrimSpace(r.PackID))]; ok {
+			e.Installed = true
+			e.Name = p.Manifest.Name
+			e.Path = p.Path
+			e.Subpacks = subpackNames(p)
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func globalPackRef(p packages.Pack, subpack string) content.GlobalPackRef {
+	v := p.Manifest.Identity.Version
+	return content.GlobalPackRef{
+		PackID:  p.Manifest.Identity.UUID,
+		Subpack: subpack,
+		Version: []int{v.Major, v.Minor, v.Patch},
+	}
+}
+
+func findGlobalPackRef(refs []content.GlobalPackRef, uuid string) int {
+	for i, r := range refs {
+		if strings.EqualFold(strings.TrimSpace(r.PackID), strings.TrimSpace(uuid)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func editGlobalResourcePacks(name string, player string, edit func(refs []content.GlobalPackRef) ([]content.GlobalPackRef, string)) string {
+	file := globalResourcePacksFile(name, player)
+	if file == "" {
+		return "ERR_NO_PLAYER"
+	}
+	refs, err := content.ReadGlobalResourcePacks(file)
+	if err != nil {
+		return "ERR_READ_FILE"
+	}
+	next, code := edit(refs)
+	if code != "" {
+		return code
+	}
+	if err := content.WriteGlobalResourcePacks(file, next); err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
+
+func SetGlobalResourcePacks(name string, player string, stack []types.GlobalResourcePack, installed []packages.Pack) string {
+	byID := resourcePacksByUUID(installed)
+	return editGlobalResourcePacks(name, player, func(current []content.GlobalPackRef) ([]content.GlobalPackRef, string) {
+		seen := map[string]struct{}{}
+		refs := make([]content.GlobalPackRef, 0, len(stack))
+		for _, e := range stack {
+			id := strings.ToLower(strings.TrimSpace(e.PackID))
+			if _, dup := seen[id]; dup {
+				return nil, "ERR_DUPLICATE_UUID"
+			}
+			seen[id] = struct{}{}
+			p, ok := byID[id]
+			if !ok {
+				i := findGlobalPackRef(current, id)
+				if i < 0 {
+					return nil, "ERR_PACK_NOT_FOUND"
+				}
+				refs = append(refs, current[i])
+				continue
+			}
+			sub := strings.TrimSpace(e.Subpack)
+			if sub != "" && !hasSubpack(p, sub) {
+				return nil, "ERR_SUBPACK_NOT_FOUND"
+			}
+			refs = append(refs, globalPackRef(p, sub))
+		}
+		return refs, ""
+	})
+}
+
+func EnableGlobalResourcePack(name string, player string, uuid string, subpack string, installed []packages.Pack) string {
+	p, ok := resourcePacksByUUID(installed)[strings.ToLower(strings.TrimSpace(uuid))]
+	if !ok {
+		return "ERR_PACK_NOT_FOUND"
+	}
+	sub := strings.TrimSpace(subpack)
+	if sub != "" && !hasSubpack(p, sub) {
+		return "ERR_SUBPACK_NOT_FOUND"
+	}
+	return editGlobalResourcePacks(name, player, func(refs []content.GlobalPackRef) ([]content.GlobalPackRef, string) {
+		next := []content.GlobalPackRef{globalPackRef(p, sub)}
+		for _, r := range refs {
+			if !strings.EqualFold(strings.TrimSpace(r.PackID), strings.TrimSpace(uuid)) {
+				next = append(next, r)
+			}
+		}
+		return next, ""
+	})
+}
+
+func DisableGlobalResourcePack(name string, player string, uuid string) string {
+	return editGlobalResourcePacks(name, player, func(refs []content.GlobalPackRef) ([]content.GlobalPackRef, string) {
+		next := make([]content.GlobalPackRef, 0, len(refs))
+		for _, r := range refs {
+			if !strings.EqualFold(strings.TrimSpace(r.PackID), strings.TrimSpace(uuid)) {
+				next = append(next, r)
+			}
+		}
+		return next, ""
+	})
+}
+
+func MoveGlobalResourcePack(name string, player string, uuid string, index int) string {
+	return editGlobalResourcePacks(name, player, func(refs []content.GlobalPackRef) ([]content.GlobalPackRef, string) {
+		from := findGlobalPackRef(refs, uuid)
+		if from < 0 {
+			return nil, "ERR_PACK_NOT_FOUND"
+		}
+		if index < 0 {
+			index = 0
+		}
+		if index >= len(refs) {
+			index = len(refs) - 1
+		}
+		r := refs[from]
+		refs = append(refs[:from], refs[from+1:]...)
+		return append(refs[:index], append([]content.GlobalPackRef{r}, refs[index:]...)...), ""
+	})
+}
+
+func SetGlobalResourcePackSubpack(name string, player string, uuid string, subpack string, installed []packages.Pack) string {
+	p, ok := resourcePacksByUUID(installed)[strings.ToLower(strings.TrimSpace(uuid))]
+	if !ok {
+		return "ERR_PACK_NOT_FOUND"
+	}
+	sub := strings.TrimSpace(subpack)
+	if sub != "" && !hasSubpack(p, sub) {
+		return "ERR_SUBPACK_NOT_FOUND"
+	}
+	return editGlobalResourcePacks(name, player, func(refs []content.GlobalPackRef) ([]content.GlobalPackRef, string) {
+		i := findGlobalPackRef(refs, uuid)
+		if i < 0 {
+			return nil, "ERR_PACK_NOT_FOUND"
+		}
+		refs[i] = globalPackRef(p, sub)
+		return refs, ""
+	})
+}
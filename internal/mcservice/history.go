@@ -0,0 +1,41 @@
+package mcservice
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+func ListWorldLevelDatHistory(worldDir string) []types.LevelDatEdit {
+	if strings.TrimSpace(worldDir) == "" {
+		return []types.LevelDatEdit{}
+	}
+	return content.ListLevelDatHistory(worldDir)
+}
+
+func PreviewWorldLevelDatRevert(worldDir string, id string) []types.LevelDatChange {
+	if strings.TrimSpace(worldDir) == "" || !utils.DirExists(worldDir) {
+		return []types.LevelDatChange{}
+	}
+	changes, err := content.PreviewLevelDatRevert(worldDir, id)
+	if err != nil {
+		return []types.LevelDatChange{}
+	}
+	return changes
+}
+
+func RevertWorldLevelDatEdit(worldDir string, id string) string {
+	if strings.TrimSpace(worldDir) == "" || !utils.DirExists(worldDir) {
+		return "ERR_INVALID_WORLD_DIR"
+	}
+	if err := content.RevertLevelDatEdit(worldDir, id); err != nil {
+		if errors.Is(err, content.ErrLevelDatEditMissing) {
+			return "ERR_HISTORY_NOT_FOUND"
+		}
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
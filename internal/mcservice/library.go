@@ -0,0 +1,531 @@
+package mcservice
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/packages"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	LibraryKindResource = "resource"
+	LibraryKindBehavior = "behavior"
+
+	libraryIndexVersion = 1
+)
+
+var libraryMu sync.Mutex
+
+type libraryIndexFile struct {
+	Version   int                       `json:"version"`
+	UpdatedAt int64                     `json:"updatedAt"`
+	Entries   map[string]*libraryRecord `json:"entries"`
+}
+
+type libraryRecord struct {
+	UUID    string             `json:"uuid"`
+	Name    string             `json:"name"`
+	Version string             `json:"version"`
+	Kind    string             `json:"kind"`
+	Size    int64              `json:"size"`
+	Refs    []types.LibraryRef `json:"refs"`
+}
+
+type libraryCandidate struct {
+	versionName string
+	kind        string
+	path        string
+	files       int
+	size        int64
+}
+
+func libraryRoot() string {
+	return filepath.Join(utils.BaseRoot(), "library")
+}
+
+func libraryPackDir(key string) string {
+	return filepath.Join(libraryRoot(), "packs", key)
+}
+
+func libraryIndexPath() string {
+	return filepath.Join(libraryRoot(), "index.json")
+}
+
+func loadLibraryIndex() libraryIndexFile {
+	idx := libraryIndexFile{Version: libraryIndexVersion, Entries: map[string]*libraryRecord{}}
+	b, err := os.ReadFile(libraryIndexPath())
+	if err != nil {
+		return idx
+	}
+	var f libraryIndexFile
+	if json.Unmarshal(b, &f) != nil || f.Version != libraryIndexVersion || f.Entries == nil {
+		return idx
+	}
+	return f
+}
+
+func saveLibraryIndex(idx libraryIndexFile) error {
+	p := libraryIndexPath()
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	idx.Version = libraryIndexVersion
+	idx.UpdatedAt = time.Now().Unix()
+	b, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(p, b, 0644)
+}
+
+func libraryPathKey(p string) string {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		abs = p
+	}
+	return strings.ToLower(filepath.Clean(abs))
+}
+
+func packTreeStats(dir string) (int, int64) {
+	files := 0
+	var size int64
+	_ = filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		files++
+		size += info.Size()
+		return nil
+	})
+	return files, size
+}
+
+func packContentHash(dir string) (string, error) {
+	h := sha256.New()
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		fh := sha256.New()
+		_, err = io.Copy(fh, f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%x\n", strings.ToLower(filepath.ToSlash(rel)), fh.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32], nil
+}
+
+func linkTree(src string, dst string) (bool, error) {
+	linked := true
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if os.Link(p, target) == nil {
+			return nil
+		}
+		linked = false
+		if err := copySyncFile(p, target); err != nil {
+			return err
+		}
+		return os.Chtimes(target, info.ModTime(), info.ModTime())
+	})
+	return linked, err
+}
+
+func isLinkedTo(entryDir string, dir string) bool {
+	a, err := os.Stat(filepath.Join(entryDir, "manifest.json"))
+	if err != nil {
+		return false
+	}
+	b, err := os.Stat(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return false
+	}
+	return os.SameFile(a, b)
+}
+
+func packKindForPath(roots types.ContentRoots, dir string) string {
+	abs, _ := filepath.Abs(dir)
+	if rp := strings.TrimSpace(roots.ResourcePacks); rp != "" && utils.IsPathWithin(rp, abs) {
+		return LibraryKindResource
+	}
+	if bp := strings.TrimSpace(roots.BehaviorPacks); bp != "" && utils.IsPathWithin(bp, abs) {
+		return LibraryKindBehavior
+	}
+	return ""
+}
+
+func newLibraryRecord(dir string, kind string) *libraryRecord {
+	info := content.ReadPackInfoFromDir(dir)
+	rec := &libraryRecord{Name: info.Name, Version: info.Version, Kind: kind}
+	if raw, err := os.ReadFile(filepath.Join(dir, "manifest.json")); err == nil {
+		if m, err := packages.ParseManifestData(raw, packages.PackTypeInvalid); err == nil {
+			rec.UUID = strings.ToLower(strings.TrimSpace(m.Identity.UUID))
+		}
+	}
+	if rec.Name == "" {
+		rec.Name = filepath.Base(dir)
+	}
+	_, rec.Size = packTreeStats(dir)
+	return rec
+}
+
+func ensureLibraryEntry(idx *libraryIndexFile, dir string, kind string) (string, error) {
+	key, err := packContentHash(dir)
+	if err != nil {
+		return "", err
+	}
+	entryDir := libraryPackDir(key)
+	if rec, ok := idx.Entries[key]; ok && utils.IsDir(entryDir) {
+		if rec.Kind == "" {
+			rec.Kind = kind
+		}
+		return key, nil
+	}
+	if err := replacePath(entryDir, ".levilauncher_link", func(tmp string) error {
+		_, err := linkTree(dir, tmp)
+		return err
+	}); err != nil {
+		return "", err
+	}
+	rec := newLibraryRecord(entryDir, kind)
+	if old, ok := idx.Entries[key]; ok {
+		rec.Refs = old.Refs
+	}
+	idx.Entries[key] = rec
+	return key, nil
+}
+
+func addLibraryRef(rec *libraryRecord, ref types.LibraryRef) {
+	k := libraryPathKey(ref.Path)
+	for i, r := range rec.Refs {
+		if libraryPathKey(r.Path) == k {
+			rec.Refs[i] = ref
+			return
+		}
+	}
+	rec.Refs = append(rec.Refs, ref)
+}
+
+func placeLibraryPack(key string, target string) (bool, error) {
+	linked := false
+	err := replacePath(target, ".levilauncher_link", func(tmp string) error {
+		var err error
+		linked, err = linkTree(libraryPackDir(key), tmp)
+		return err
+	})
+	return linked, err
+}
+
+func releaseLibraryRefsLocked(idx *libraryIndexFile, match func(key string, r types.LibraryRef) bool) bool {
+	changed := false
+	for key, rec := range idx.Entries {
+		kept := rec.Refs[:0]
+		for _, r := range rec.Refs {
+			if match(key, r) {
+				changed = true
+				continue
+			}
+			kept = append(kept, r)
+		}
+		rec.Refs = kept
+		if len(rec.Refs) == 0 {
+			_ = os.RemoveAll(libraryPackDir(key))
+			delete(idx.Entries, key)
+			changed = true
+		}
+	}
+	return changed
+}
+
+func GetLibraryInfo() types.LibraryInfo {
+	libraryMu.Lock()
+	defer libraryMu.Unlock()
+	res := types.LibraryInfo{Root: libraryRoot(), Entries: []types.LibraryEntry{}}
+	idx := loadLibraryIndex()
+	for key, rec := range idx.Entries {
+		e := types.LibraryEntry{
+			Key:     key,
+			UUID:    rec.UUID,
+			Name:    rec.Name,
+			Version: rec.Version,
+			Kind:    rec.Kind,
+			Path:    libraryPackDir(key),
+			Size:    rec.Size,
+			Refs:    append([]types.LibraryRef{}, rec.Refs...),
+		}
+		res.TotalSize += rec.Size
+		linked := 0
+		for _, r := range rec.Refs {
+			if r.Linked {
+				linked++
+			}
+		}
+		if linked > 1 {
+			res.SavedBytes += int64(linked-1) * rec.Size
+		}
+		res.Entries = append(res.Entries, e)
+	}
+	sort.Slice(res.Entries, func(i, j int) bool {
+		if !strings.EqualFold(res.Entries[i].Name, res.Entries[j].Name) {
+			return strings.ToLower(res.Entries[i].Name) < strings.ToLower(res.Entries[j].Name)
+		}
+		return res.Entries[i].Key < res.Entries[j].Key
+	})
+	return res
+}
+
+func AddPackToLibrary(name string, packDir string) string {
+	dir := strings.TrimSpace(packDir)
+	if dir == "" || !utils.IsDir(dir) || !utils.FileExists(filepath.Join(dir, "manifest.json")) {
+		return "ERR_INVALID_PATH"
+	}
+	kind := packKindForPath(GetContentRoots(name), dir)
+	if kind == "" {
+		return "ERR_INVALID_PACKAGE"
+	}
+	libraryMu.Lock()
+	defer libraryMu.Unlock()
+	idx := loadLibraryIndex()
+	key, err := ensureLibraryEntry(&idx, dir, kind)
+	if err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	linked := isLinkedTo(libraryPackDir(key), dir)
+	if !linked {
+		if linked, err = placeLibraryPack(key, dir); err != nil {
+			return "ERR_WRITE_FILE"
+		}
+	}
+	addLibraryRef(idx.Entries[key], types.LibraryRef{VersionName: strings.TrimSpace(name), Path: dir, Linked: linked})
+	if err := saveLibraryIndex(idx); err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
+
+func LinkLibraryPack(name string, key string) string {
+	k := strings.ToLower(strings.TrimSpace(key))
+	if k == "" {
+		return "ERR_INVALID_PACKAGE"
+	}
+	roots := GetContentRoots(name)
+	libraryMu.Lock()
+	defer libraryMu.Unlock()
+	idx := loadLibraryIndex()
+	rec, ok := idx.Entries[k]
+	if !ok || !utils.IsDir(libraryPackDir(k)) {
+		return "ERR_NOT_FOUND"
+	}
+	parent := roots.ResourcePacks
+	if rec.Kind == LibraryKindBehavior {
+		parent = roots.BehaviorPacks
+	}
+	if strings.TrimSpace(parent) == "" {
+		return "ERR_ACCESS_VERSIONS_DIR"
+	}
+	folder := utils.SanitizeFilename(rec.Name)
+	if folder == "" {
+		folder = k
+	}
+	target := filepath.Join(parent, folder)
+	for i := 2; utils.DirExists(target); i++ {
+		if isLinkedTo(libraryPackDir(k), target) {
+			break
+		}
+		target = filepath.Join(parent, fmt.Sprintf("%s (%d)", folder, i))
+	}
+	linked, err := placeLibraryPack(k, target)
+	if err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	addLibraryRef(rec, types.LibraryRef{VersionName: strings.TrimSpace(name), Path: target, Linked: linked})
+	if err := saveLibraryIndex(idx); err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
+
+func ReleaseLibraryPath(path string) {
+	p := strings.TrimSpace(path)
+	if p == "" {
+		return
+	}
+	key := libraryPathKey(p)
+	libraryMu.Lock()
+	defer libraryMu.Unlock()
+	idx := loadLibraryIndex()
+	if releaseLibraryRefsLocked(&idx, func(_ string, r types.LibraryRef) bool {
+		rk := libraryPathKey(r.Path)
+		return rk == key || strings.HasPrefix(rk, key+string(os.PathSeparator))
+	}) {
+		_ = saveLibraryIndex(idx)
+	}
+}
+
+func PruneLibrary() types.LibraryInfo {
+	libraryMu.Lock()
+	idx := loadLibraryIndex()
+	changed := releaseLibraryRefsLocked(&idx, func(key string, r types.LibraryRef) bool {
+		if !utils.IsDir(r.Path) || !utils.FileExists(filepath.Join(r.Path, "manifest.json")) {
+			return true
+		}
+		if r.Linked {
+			return !isLinkedTo(libraryPackDir(key), r.Path)
+		}
+		h, err := packContentHash(r.Path)
+		return err != nil || h != key
+	})
+	if ents, err := os.ReadDir(filepath.Join(libraryRoot(), "packs")); err == nil {
+		for _, e := range ents {
+			if _, ok := idx.Entries[e.Name()]; !ok {
+				_ = os.RemoveAll(filepath.Join(libraryRoot(), "packs", e.Name()))
+			}
+		}
+	}
+	if changed {
+		_ = saveLibraryIndex(idx)
+	}
+	libraryMu.Unlock()
+	return GetLibraryInfo()
+}
+
+func collectLibraryCandidates() []libraryCandidate {
+	var out []libraryCandidate
+	for _, m := range ListVersionMetas() {
+		if !m.EnableIsolation {
+			continue
+		}
+		roots := GetContentRoots(m.Name)
+		for _, d := range []struct{ kind, dir string }{
+			{LibraryKindResource, roots.ResourcePacks},
+			{LibraryKindBehavior, roots.BehaviorPacks},
+		} {
+			if strings.TrimSpace(d.dir) == "" {
+				continue
+			}
+			for _, n := range listSyncEntries(d.dir, true) {
+				p := filepath.Join(d.dir, n)
+				if !utils.FileExists(filepath.Join(p, "manifest.json")) {
+					continue
+				}
+				files, size := packTreeStats(p)
+				out = append(out, libraryCandidate{versionName: m.Name, kind: d.kind, path: p, files: files, size: size})
+			}
+		}
+	}
+	return out
+}
+
+func ConvertDuplicatesToShared(dryRun bool) types.LibraryDedupeResult {
+	res := types.LibraryDedupeResult{DryRun: dryRun, Groups: []types.LibraryDedupeGroup{}, Converted: []string{}, Failed: map[string]string{}}
+	bySig := map[string][]libraryCandidate{}
+	for _, c := range collectLibraryCandidates() {
+		sig := fmt.Sprintf("%s|%d|%d", c.kind, c.files, c.size)
+		bySig[sig] = append(bySig[sig], c)
+	}
+	libraryMu.Lock()
+	defer libraryMu.Unlock()
+	idx := loadLibraryIndex()
+	groups := map[string][]libraryCandidate{}
+	for _, cs := range bySig {
+		if len(cs) < 2 {
+			continue
+		}
+		for _, c := range cs {
+			key, err := packContentHash(c.path)
+			if err != nil {
+				res.Failed[c.path] = "ERR_READ_FILE"
+				continue
+			}
+			groups[key] = append(groups[key], c)
+		}
+	}
+	keys := make([]string, 0, len(groups))
+	for key, cs := range groups {
+		if len(cs) >= 2 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		cs := groups[key]
+		g := types.LibraryDedupeGroup{Key: key, Name: content.ReadPackInfoFromDir(cs[0].path).Name, Kind: cs[0].kind, Size: cs[0].size}
+		for _, c := range cs {
+			g.Paths = append(g.Paths, c.path)
+		}
+		res.Groups = append(res.Groups, g)
+		if dryRun {
+			res.SavedBytes += int64(len(cs)-1) * g.Size
+			continue
+		}
+		if _, err := ensureLibraryEntry(&idx, cs[0].path, cs[0].kind); err != nil {
+			res.Failed[cs[0].path] = "ERR_WRITE_FILE"
+			continue
+		}
+		rec := idx.Entries[key]
+		for _, c := range cs {
+			linked := isLinkedTo(libraryPackDir(key), c.path)
+			if !linked {
+				var err error
+				if linked, err = placeLibraryPack(key, c.path); err != nil {
+					res.Failed[c.path] = "ERR_WRITE_FILE"
+					continue
+				}
+				if linked {
+					res.SavedBytes += c.size
+				}
+			}
+			addLibraryRef(rec, types.LibraryRef{VersionName: c.versionName, Path: c.path, Linked: linked})
+			res.Converted = append(res.Converted, c.path)
+		}
+	}
+	if !dryRun && len(keys) > 0 {
+		if err := saveLibraryIndex(idx); err != nil {
+			res.Error = "ERR_WRITE_FILE"
+		}
+	}
+	return res
+}
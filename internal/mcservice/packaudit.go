@@ -0,0 +1,40 @@
+package mcservice
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/types"
+)
+
+func auditPackDirs(roots types.ContentRoots) []string {
+	dirs := []string{roots.ResourcePacks, roots.BehaviorPacks}
+	if strings.TrimSpace(roots.ResourcePacks) != "" {
+		dirs = append(dirs, filepath.Join(filepath.Dir(roots.ResourcePacks), "development_resource_packs"))
+	}
+	if d := developmentBehaviorPacksDir(roots); d != "" {
+		dirs = append(dirs, d)
+	}
+	return dirs
+}
+
+func AuditVersionPacks(name string) types.PackAudit {
+	return content.AuditPacks(auditPackDirs(GetContentRoots(name))...)
+}
+
+func ResolvePackDuplicates(name string, uuid string, strategy string, keepPath string, remove func(path string) error) string {
+	err := content.ResolvePackDuplicates(uuid, strategy, keepPath, remove, auditPackDirs(GetContentRoots(name))...)
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, content.ErrInvalidResolveStrategy):
+		return "ERR_INVALID_STRATEGY"
+	case errors.Is(err, content.ErrPackCopyNotFound):
+		return "ERR_NOT_FOUND"
+	case content.ProtectionErrorCode(err) != "":
+		return content.ProtectionErrorCode(err)
+	}
+	return "ERR_WRITE_FILE"
+}
@@ -0,0 +1,105 @@
+package mcservice
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/packages"
+)
+
+type packArchive struct {
+	path      string
+	manifests []packages.PackManifest
+}
+
+func readPackArchive(p string) (packArchive, bool) {
+	a := packArchive{path: p}
+	for _, raw := range content.ReadArchiveFileManifests(p) {
+		if m, err := packages.ParseManifestData(raw, packages.PackTypeInvalid); err == nil {
+			a.manifests = append(a.manifests, m)
+		}
+	}
+	return a, len(a.manifests) > 0
+}
+
+func selectedPackArchives(primary string, selected []string) []packArchive {
+	var out []packArchive
+	seen := map[string]struct{}{strings.ToLower(filepath.Clean(primary)): {}}
+	for _, sp := range selected {
+		sp = strings.TrimSpace(sp)
+		key := strings.ToLower(filepath.Clean(sp))
+		if _, ok := seen[key]; ok || sp == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		if a, ok := readPackArchive(sp); ok {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func providesDependency(a packArchive, d packages.PackDependency) bool {
+	for _, m := range a.manifests {
+		if strings.EqualFold(strings.TrimSpace(m.Identity.UUID), d.UUID) && m.Identity.Version.Compare(d.Version) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func PlanPackImportPath(path string, selected []string, installed []packages.Pack) packages.ImportPlan {
+	plan := packages.ImportPlan{Archives: []string{}, Imported: []string{}, Missing: []packages.DependencyIssue{}}
+	primary, ok := readPackArchive(path)
+	if !ok {
+		plan.Error = "ERR_MANIFEST_NOT_FOUND"
+		return plan
+	}
+	have := make([]packages.PackManifest, 0, len(installed))
+	for _, p := range installed {
+		have = append(have, p.Manifest)
+	}
+	plan.Archives = append(plan.Archives, primary.path)
+	incoming := append([]packages.PackManifest{}, primary.manifests...)
+	candidates := selectedPackArchives(path, selected)
+	used := make([]bool, len(candidates))
+	for {
+		issues := packages.UnresolvedDependencies(incoming, have)
+		progress := false
+		for _, issue := range issues {
+			for i, c := range candidates {
+				if used[i] || !providesDependency(c, issue.Dependency) {
+					continue
+				}
+				used[i] = true
+				plan.Archives = append(plan.Archives, c.path)
+				incoming = append(incoming, c.manifests...)
+				progress = true
+				break
+			}
+		}
+		if !progress {
+			plan.Missing = issues
+			return plan
+		}
+	}
+}
+
+func ImportPackClosurePath(name string, player string, path string, selected []string, overwrite bool, installed []packages.Pack) packages.ImportPlan {
+	plan := PlanPackImportPath(path, selected, installed)
+	if plan.Error != "" {
+		return plan
+	}
+	roots := GetContentRoots(name)
+	skinDir := ""
+	if users := strings.TrimSpace(roots.UsersRoot); users != "" && strings.TrimSpace(player) != "" {
+		skinDir = filepath.Join(users, player, "games", "com.mojang", "skin_packs")
+	}
+	if code := content.ImportPackFilesToDirs2(plan.Archives, roots.ResourcePacks, roots.BehaviorPacks, skinDir, overwrite); code != "" {
+		plan.Error = code
+		return plan
+	}
+	plan.Imported = append(plan.Imported, plan.Archives...)
+	return plan
+}
@@ -0,0 +1,29 @@
+package mcservice
+
+import (
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/packages"
+	"github.com/liteldev/LeviLauncher/internal/types"
+)
+
+func DiffPacks(oldPath string, newPath string) types.PackDiff {
+	return content.DiffPacks(strings.TrimSpace(oldPath), strings.TrimSpace(newPath))
+}
+
+func PreviewPackUpdate(path string, installed []packages.Pack) []types.PackDiff {
+	byID := map[string]packages.Pack{}
+	for _, p := range installed {
+		id := strings.ToLower(strings.TrimSpace(p.Manifest.Identity.UUID))
+		if cur, ok := byID[id]; id != "" && (!ok || cur.Manifest.Identity.Version.Compare(p.Manifest.Identity.Version) < 0) {
+			byID[id] = p
+		}
+	}
+	return content.DiffPackUpdate(strings.TrimSpace(path), func(uuid string) string {
+		if p, ok := byID[strings.ToLower(uuid)]; ok {
+			return p.Path
+		}
+		return ""
+	})
+}
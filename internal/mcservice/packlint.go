@@ -0,0 +1,26 @@
+package mcservice
+
+import (
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+func LintPackPath(name string, path string) []types.PackLintReport {
+	gv := targetGameVersion(name)
+	p := strings.TrimSpace(path)
+	if utils.IsDir(p) {
+		return []types.PackLintReport{content.LintPackDir(p, gv)}
+	}
+	return content.LintPackArchiveFile(p, gv)
+}
+
+func LintPackData(name string, fileName string, data []byte) []types.PackLintReport {
+	return content.LintPackArchive(data, fileName, targetGameVersion(name))
+}
+
+func GetPackTranslationCoverage(path string) types.PackTranslationCoverage {
+	return content.PackTranslationCoverage(strings.TrimSpace(path))
+}
@@ -0,0 +1,47 @@
+package mcservice
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+func contentAreas(name string) []string {
+	roots := GetContentRoots(name)
+	var areas []string
+	if strings.TrimSpace(roots.ResourcePacks) != "" {
+		areas = append(areas, filepath.Dir(roots.ResourcePacks))
+	}
+	for _, p := range listPlayers(roots.UsersRoot) {
+		areas = append(areas, filepath.Join(roots.UsersRoot, p, "games", "com.mojang"))
+	}
+	return areas
+}
+
+func ListReplacedContent(name string) []types.ReplacedContent {
+	return content.ListReplacedContent(contentAreas(name)...)
+}
+
+func RestoreReplacedContent(name string, area string, id string) string {
+	ok := false
+	for _, a := range contentAreas(name) {
+		if strings.EqualFold(filepath.Clean(a), filepath.Clean(area)) && utils.DirExists(a) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return "ERR_NOT_FOUND"
+	}
+	if err := content.RestoreReplacedContent(area, id); err != nil {
+		if errors.Is(err, content.ErrReplacedContentMissing) {
+			return "ERR_NOT_FOUND"
+		}
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package mcservice
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/scriptapi"
+	"github.com/liteldev/LeviLauncher/internal/types"
+)
+
+func minEngineFromGameVersion(v string) []int {
+	parts := strings.Split(strings.TrimSpace(v), ".")
+	if len(parts) < 3 {
+		return nil
+	}
+	out := make([]int, 3)
+	for i := 0; i < 3; i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return nil
+		}
+		out[i] = n
+	}
+	return out
+}
+
+func ScaffoldPack(name string, req types.PackScaffoldRequest) types.PackScaffoldResult {
+	game := targetGameVersion(name)
+	if len(req.MinEngineVersion) != 3 {
+		req.MinEngineVersion = minEngineFromGameVersion(game)
+	}
+	if req.ScriptModule && strings.TrimSpace(req.ScriptVersion) == "" && game != "" {
+		table, _ := scriptapi.Load()
+		for _, v := range table.Supported("@minecraft/server", game) {
+			if !scriptapi.IsBeta(v) {
+				req.ScriptVersion = v
+				break
+			}
+		}
+	}
+	if strings.TrimSpace(req.OutputDir) != "" {
+		return content.ScaffoldPack(req)
+	}
+	bpParent := devPackParent(name, LibraryKindBehavior)
+	rpParent := devPackParent(name, LibraryKindResource)
+	if bpParent == "" || rpParent == "" {
+		return types.PackScaffoldResult{Packs: []types.ScaffoldedPack{}, Error: "ERR_ACCESS_VERSIONS_DIR"}
+	}
+	if err := os.MkdirAll(bpParent, 0755); err != nil {
+		return types.PackScaffoldResult{Packs: []types.ScaffoldedPack{}, Error: "ERR_CREATE_TARGET_DIR"}
+	}
+	stage, err := os.MkdirTemp(bpParent, ".levilauncher_scaffold_*")
+	if err != nil {
+		return types.PackScaffoldResult{Packs: []types.ScaffoldedPack{}, Error: "ERR_CREATE_TARGET_DIR"}
+	}
+	defer os.RemoveAll(stage)
+	req.OutputDir = stage
+	res := content.ScaffoldPack(req)
+	if res.Error != "" {
+		return res
+	}
+	moved := make([]types.ScaffoldedPack, 0, len(res.Packs))
+	for _, p := range res.Packs {
+		parent := bpParent
+		if p.Kind == content.ScaffoldResource {
+			parent = rpParent
+		}
+		dest := filepath.Join(parent, filepath.Base(p.Path))
+		if _, err := os.Lstat(dest); err == nil {
+			res.Error = "ERR_TARGET_EXISTS"
+		} else if err := os.MkdirAll(parent, 0755); err != nil {
+			res.Error = "ERR_CREATE_TARGET_DIR"
+		} else if err := os.Rename(p.Path, dest); err != nil {
+			res.Error = "ERR_WRITE_FILE"
+		}
+		if res.Error != "" {
+			for _, m := range moved {
+				_ = os.RemoveAll(m.Path)
+			}
+			res.Packs = []types.ScaffoldedPack{}
+			return res
+		}
+		p.Path = dest
+		moved = append(moved, p)
+	}
+	res.Packs = moved
+	return res
+}
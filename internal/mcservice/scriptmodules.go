@@ -0,0 +1,162 @@
+package mcservice
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/packages"
+	"github.com/liteldev/LeviLauncher/internal/scriptapi"
+	"github.com/liteldev/LeviLauncher/internal/types"
+)
+
+const (
+	maxSupportedSuggestions = 5
+
+	ScriptPackInstalled = "installed"
+	ScriptPackDev       = "dev"
+	ScriptPackWorld     = "world"
+)
+
+func scriptTableInfo(t *scriptapi.Table, source string) types.ScriptModuleTableInfo {
+	return types.ScriptModuleTableInfo{Revision: t.Revision, Updated: t.Updated, CoveredGame: t.CoveredGame, Source: source, Modules: len(t.Modules)}
+}
+
+func GetScriptModuleTableInfo() types.ScriptModuleTableInfo {
+	return scriptTableInfo(scriptapi.Load())
+}
+
+func UpdateScriptModuleTable(url string) types.ScriptModuleTableInfo {
+	u := strings.TrimSpace(url)
+	if !strings.HasPrefix(strings.ToLower(u), "https://") {
+		return types.ScriptModuleTableInfo{Error: "ERR_INVALID_URL"}
+	}
+	if _, err := scriptapi.Download(u); err != nil {
+		info := GetScriptModuleTableInfo()
+		info.Error = "ERR_SCRIPT_TABLE_UPDATE"
+		return info
+	}
+	return GetScriptModuleTableInfo()
+}
+
+func ImportScriptModuleTable(data []byte) types.ScriptModuleTableInfo {
+	if _, err := scriptapi.Install(data); err != nil {
+		info := GetScriptModuleTableInfo()
+		info.Error = "ERR_SCRIPT_TABLE_INVALID"
+		return info
+	}
+	return GetScriptModuleTableInfo()
+}
+
+func ResetScriptModuleTable() types.ScriptModuleTableInfo {
+	scriptapi.Reset()
+	return GetScriptModuleTableInfo()
+}
+
+func worldScriptPackDirs(name string) []string {
+	var out []string
+	for _, w := range listWorldDirs(GetContentRoots(name).UsersRoot) {
+		out = append(out, filepath.Join(w.Dir, "behavior_packs"))
+	}
+	return out
+}
+
+func readBehaviorPackDirs(dirs ...string) []packages.Pack {
+	var out []packages.Pack
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		ents, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range ents {
+			if !e.IsDir() {
+				continue
+			}
+			if p, err := packages.ReadPackDir(filepath.Join(dir, e.Name()), packages.PackTypeBehavior); err == nil {
+				out = append(out, p)
+			}
+		}
+	}
+	return out
+}
+
+func CheckScriptModules(name string, installed []packages.Pack) []types.ScriptModuleIssue {
+	out := []types.ScriptModuleIssue{}
+	game := targetGameVersion(name)
+	table, _ := scriptapi.Load()
+	seen := map[string]struct{}{}
+	groups := []struct {
+		source string
+		packs  []packages.Pack
+	}{
+		{ScriptPackInstalled, installed},
+		{ScriptPackDev, readBehaviorPackDirs(devPackParent(name, LibraryKindBehavior))},
+		{ScriptPackWorld, readBehaviorPackDirs(worldScriptPackDirs(name)...)},
+	}
+	for _, g := range groups {
+		for _, p := range g.packs {
+			if p.Manifest.PackType != packages.PackTypeBehavior {
+				continue
+			}
+			key := strings.ToLower(filepath.Clean(p.Path))
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			out = append(out, scriptPackIssues(p, g.source, table, game)...)
+		}
+	}
+	return out
+}
+
+func scriptPackIssues(p packages.Pack, source string, table *scriptapi.Table, game string) []types.ScriptModuleIssue {
+	var out []types.ScriptModuleIssue
+	for _, d := range p.Manifest.Dependencies {
+		module := strings.ToLower(strings.TrimSpace(d.ModuleName))
+		if !strings.HasPrefix(module, "@minecraft/") {
+			continue
+		}
+		version := d.VersionRaw
+		if version == "" {
+			version = d.Version.String()
+		}
+		status := table.Check(module, version, game)
+		if status == scriptapi.StatusOK {
+			continue
+		}
+		severity := "warning"
+		if status == scriptapi.StatusUnsupported {
+			severity = "error"
+		}
+		supported := table.Supported(module, game)
+		if len(supported) > maxSupportedSuggestions {
+			supported = supported[:maxSupportedSuggestions]
+		}
+		out = append(out, types.ScriptModuleIssue{
+			PackName:    p.Manifest.Name,
+			PackPath:    p.Path,
+			PackUUID:    p.Manifest.Identity.UUID,
+			Module:      module,
+			Version:     version,
+			Status:      status,
+			Severity:    severity,
+			GameVersion: game,
+			Supported:   supported,
+			Source:      source,
+		})
+	}
+	return out
+}
+
+func BlockingScriptModuleIssues(issues []types.ScriptModuleIssue) []types.ScriptModuleIssue {
+	out := []types.ScriptModuleIssue{}
+	for _, i := range issues {
+		if i.Severity == "error" && i.Source != ScriptPackWorld {
+			out = append(out, i)
+		}
+	}
+	return out
+}
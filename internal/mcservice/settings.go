@@ -5,6 +5,7 @@ import (
 
 	"github.com/liteldev/LeviLauncher/internal/config"
 	"github.com/liteldev/LeviLauncher/internal/discord"
+	"github.com/liteldev/LeviLauncher/internal/lang"
 	"github.com/liteldev/LeviLauncher/internal/utils"
 )
 
@@ -59,3 +60,25 @@ func SetDisableDiscordRPC(disable bool) string {
 	}
 	return ""
 }
+
+func GetLauncherLanguage() string {
+	return lang.Current()
+}
+
+func SetLauncherLanguage(code string) string {
+	l := lang.NormalizeCode(code)
+	if l == "" {
+		return "ERR_INVALID_LANGUAGE"
+	}
+	c, _ := config.Load()
+	if lang.NormalizeCode(c.Language) == l {
+		lang.SetCurrent(l)
+		return ""
+	}
+	c.Language = l
+	if err := config.Save(c); err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	lang.SetCurrent(l)
+	return ""
+}
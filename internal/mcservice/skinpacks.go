@@ -0,0 +1,39 @@
+package mcservice
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+func GetSkinPackInfo(path string) types.SkinPackInfo {
+	p := strings.TrimSpace(path)
+	if utils.IsDir(p) {
+		return content.ReadSkinPackDir(p)
+	}
+	return content.ReadSkinPackArchiveFile(p)
+}
+
+func BuildSkinPack(req types.SkinPackBuildRequest) types.SkinPackBuildResult {
+	return content.BuildSkinPack(req)
+}
+
+func BuildAndInstallSkinPack(name string, player string, req types.SkinPackBuildRequest) types.SkinPackBuildResult {
+	roots := GetContentRoots(name)
+	users := strings.TrimSpace(roots.UsersRoot)
+	if users == "" || strings.TrimSpace(player) == "" {
+		return types.SkinPackBuildResult{Error: "ERR_NO_PLAYER"}
+	}
+	res := content.BuildSkinPack(req)
+	if res.Error != "" {
+		return res
+	}
+	skinDir := filepath.Join(users, player, "games", "com.mojang", "skin_packs")
+	if code := content.ImportMcpackFileToDirs2(res.Path, roots.ResourcePacks, roots.BehaviorPacks, skinDir, true); code != "" {
+		res.Error = code
+	}
+	return res
+}
@@ -0,0 +1,327 @@
+package mcservice
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	SyncWorlds        = "worlds"
+	SyncResourcePacks = "resource_packs"
+	SyncBehaviorPacks = "behavior_packs"
+	SyncSkinPacks     = "skin_packs"
+	SyncOptions       = "options"
+	SyncScreenshots   = "screenshots"
+
+	SyncNewerWins  = "newer_wins"
+	SyncSourceWins = "source_wins"
+	SyncSkip       = "skip"
+)
+
+var syncCategories = []string{SyncWorlds, SyncResourcePacks, SyncBehaviorPacks, SyncSkinPacks, SyncOptions, SyncScreenshots}
+
+func syncBase(e types.SyncEndpoint) string {
+	if e.GDK {
+		return strings.TrimSpace(utils.GetMinecraftGDKDataPath(e.Preview))
+	}
+	if strings.TrimSpace(e.VersionName) == "" {
+		return ""
+	}
+	return strings.TrimSpace(GetContentRoots(e.VersionName).Base)
+}
+
+func statSyncPath(p string) (int64, int64, bool) {
+	fi, err := os.Stat(p)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !fi.IsDir() {
+		return fi.Size(), fi.ModTime().Unix(), true
+	}
+	var size, mod int64
+	_ = filepath.Walk(p, func(_ string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		size += info.Size()
+		if m := info.ModTime().Unix(); m > mod {
+			mod = m
+		}
+		return nil
+	})
+	return size, mod, true
+}
+
+func listSyncEntries(dir string, wantDir bool) []string {
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []string
+	for _, e := range ents {
+		if e.IsDir() == wantDir {
+			out = append(out, e.Name())
+		}
+	}
+	return out
+}
+
+func collectSyncItems(base string, categories []string) []types.SyncItem {
+	want := map[string]bool{}
+	for _, c := range categories {
+		want[strings.ToLower(strings.TrimSpace(c))] = true
+	}
+	if len(want) == 0 {
+		for _, c := range syncCategories {
+			want[c] = true
+		}
+	}
+	users := filepath.Join(base, "Users")
+	var items []types.SyncItem
+	add := func(category string, player string, p string, name string) {
+		rel, err := filepath.Rel(base, p)
+		if err != nil {
+			return
+		}
+		items = append(items, types.SyncItem{Category: category, RelPath: filepath.ToSlash(rel), Name: name, Player: player})
+	}
+	shared := filepath.Join(users, "Shared", "games", "com.mojang")
+	for _, c := range []string{SyncResourcePacks, SyncBehaviorPacks} {
+		if !want[c] {
+			continue
+		}
+		for _, n := range listSyncEntries(filepath.Join(shared, c), true) {
+			add(c, "", filepath.Join(shared, c, n), n)
+		}
+	}
+	if want[SyncSkinPacks] {
+		for _, n := range listSyncEntries(filepath.Join(shared, "skin_packs"), true) {
+			add(SyncSkinPacks, "", filepath.Join(shared, "skin_packs", n), n)
+		}
+	}
+	if want[SyncWorlds] {
+		for _, w := range listWorldDirs(users) {
+			name := GetWorldLevelName(w.Dir)
+			if name == "" {
+				name = filepath.Base(w.Dir)
+			}
+			add(SyncWorlds, w.Player, w.Dir, name)
+		}
+	}
+	for _, player := range listPlayers(users) {
+		mojang := filepath.Join(users, player, "games", "com.mojang")
+		if want[SyncSkinPacks] {
+			for _, n := range listSyncEntries(filepath.Join(mojang, "skin_packs"), true) {
+				add(SyncSkinPacks, player, filepath.Join(mojang, "skin_packs", n), n)
+			}
+		}
+		if want[SyncOptions] {
+			if p := filepath.Join(mojang, "minecraftpe", "options.txt"); utils.FileExists(p) {
+				add(SyncOptions, player, p, "options.txt")
+			}
+		}
+		if want[SyncScreenshots] {
+			dir := filepath.Join(mojang, "Screenshots")
+			for _, n := range listSyncEntries(dir, false) {
+				add(SyncScreenshots, player, filepath.Join(dir, n), n)
+			}
+			for _, n := range listSyncEntries(dir, true) {
+				add(SyncScreenshots, player, filepath.Join(dir, n), n)
+			}
+		}
+	}
+	return items
+}
+
+func fillSyncTarget(src string, dst string, it *types.SyncItem) {
+	it.Size, it.ModTime, _ = statSyncPath(filepath.Join(src, filepath.FromSlash(it.RelPath)))
+	it.TargetSize, it.TargetModTime, it.Exists = statSyncPath(filepath.Join(dst, filepath.FromSlash(it.RelPath)))
+	switch {
+	case !it.Exists:
+		it.Newer = "source"
+	case it.Size == it.TargetSize && it.ModTime == it.TargetModTime:
+		it.Newer = "same"
+	case it.ModTime > it.TargetModTime:
+		it.Newer = "source"
+	default:
+		it.Newer = "target"
+	}
+	it.Conflict = it.Exists && it.Newer != "same"
+}
+
+func resolveSyncBases(source types.SyncEndpoint, target types.SyncEndpoint) (string, string, string) {
+	src := syncBase(source)
+	dst := syncBase(target)
+	if src == "" || !utils.IsDir(src) {
+		return "", "", "ERR_INHERIT_SOURCE_NOT_FOUND"
+	}
+	if dst == "" {
+		return "", "", "ERR_INHERIT_TARGET_NOT_FOUND"
+	}
+	if strings.EqualFold(filepath.Clean(src), filepath.Clean(dst)) {
+		return "", "", "ERR_SYNC_SAME_ENDPOINT"
+	}
+	return src, dst, ""
+}
+
+func PreviewContentSync(source types.SyncEndpoint, target types.SyncEndpoint, categories []string) types.SyncPreview {
+	res := types.SyncPreview{Items: []types.SyncItem{}}
+	src, dst, code := resolveSyncBases(source, target)
+	if code != "" {
+		res.Error = code
+		return res
+	}
+	res.Source, res.Target = src, dst
+	for _, it := range collectSyncItems(src, categories) {
+		fillSyncTarget(src, dst, &it)
+		res.Items = append(res.Items, it)
+	}
+	sort.SliceStable(res.Items, func(i, j int) bool {
+		if res.Items[i].Category != res.Items[j].Category {
+			return res.Items[i].Category < res.Items[j].Category
+		}
+		return strings.ToLower(res.Items[i].RelPath) < strings.ToLower(res.Items[j].RelPath)
+	})
+	return res
+}
+
+func copySyncFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func copySyncTree(src string, dst string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if err := copySyncFile(p, target); err != nil {
+			return err
+		}
+		return os.Chtimes(target, info.ModTime(), info.ModTime())
+	})
+}
+
+func syncCopy(src string, dst string) error {
+	if err := replacePath(dst, ".levilauncher_sync", func(tmp string) error {
+		return copySyncTree(src, tmp)
+	}); err != nil {
+		return err
+	}
+	ReleaseLibraryPath(dst)
+	return nil
+}
+
+func replacePath(dst string, suffix string, fill func(tmp string) error) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	tmp := dst + suffix
+	_ = os.RemoveAll(tmp)
+	if err := fill(tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	old := dst + suffix + "_old"
+	hadOld := false
+	if _, err := os.Stat(dst); err == nil {
+		_ = os.RemoveAll(old)
+		if err := os.Rename(dst, old); err != nil {
+			_ = os.RemoveAll(tmp)
+			return err
+		}
+		hadOld = true
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		if hadOld {
+			_ = os.Rename(old, dst)
+		}
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	if hadOld {
+		_ = os.RemoveAll(old)
+	}
+	return nil
+}
+
+func SyncContent(req types.SyncRequest) types.SyncResult {
+	res := types.SyncResult{Copied: []string{}, Skipped: []string{}, Failed: map[string]string{}}
+	strategy := strings.ToLower(strings.TrimSpace(req.Strategy))
+	switch strategy {
+	case "":
+		strategy = SyncNewerWins
+	case SyncNewerWins, SyncSourceWins, SyncSkip:
+	default:
+		res.Error = "ERR_INVALID_STRATEGY"
+		return res
+	}
+	src, dst, code := resolveSyncBases(req.Source, req.Target)
+	if code != "" {
+		res.Error = code
+		return res
+	}
+	byRel := map[string]types.SyncItem{}
+	for _, it := range collectSyncItems(src, nil) {
+		byRel[strings.ToLower(it.RelPath)] = it
+	}
+	for _, rel := range req.Items {
+		key := strings.ToLower(filepath.ToSlash(strings.TrimSpace(rel)))
+		it, ok := byRel[key]
+		if !ok {
+			res.Failed[rel] = "ERR_NOT_FOUND"
+			continue
+		}
+		fillSyncTarget(src, dst, &it)
+		if it.Exists {
+			skip := it.Newer == "same"
+			switch strategy {
+			case SyncSkip:
+				skip = true
+			case SyncNewerWins:
+				skip = skip || it.Newer != "source"
+			}
+			if skip {
+				res.Skipped = append(res.Skipped, it.RelPath)
+				continue
+			}
+		}
+		from := filepath.Join(src, filepath.FromSlash(it.RelPath))
+		to := filepath.Join(dst, filepath.FromSlash(it.RelPath))
+		if err := syncCopy(from, to); err != nil {
+			res.Failed[it.RelPath] = "ERR_INHERIT_COPY_FAILED"
+			continue
+		}
+		res.Copied = append(res.Copied, it.RelPath)
+	}
+	return res
+}
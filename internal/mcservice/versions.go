@@ -206,22 +206,9 @@ func GetContentCounts(name string) ContentCounts {
 	bp := countDirs(roots.BehaviorPacks)
 	worlds := 0
 	usersRoot := strings.TrimSpace(roots.UsersRoot)
-	if usersRoot != "" {
-		ents, _ := os.ReadDir(usersRoot)
-		var firstPlayer string
-		for _, e := range ents {
-			if e.IsDir() {
-				nm := strings.TrimSpace(e.Name())
-				if nm != "" && !strings.EqualFold(nm, "Shared") {
-					firstPlayer = nm
-					break
-				}
-			}
-		}
-		if firstPlayer != "" {
-			wp := filepath.Join(usersRoot, firstPlayer, "games", "com.mojang", "minecraftWorlds")
-			worlds = countDirs(wp)
-		}
+	for _, player := range listPlayers(usersRoot) {
+		wp := filepath.Join(usersRoot, player, "games", "com.mojang", "minecraftWorlds")
+		worlds += countDirs(wp)
 	}
 	return ContentCounts{Worlds: worlds, ResourcePacks: res, BehaviorPacks: bp}
 }
@@ -697,6 +684,7 @@ func DeleteVersionFolder(name string) string {
 	if err := os.RemoveAll(dir); err != nil {
 		return "ERR_DELETE_FAILED"
 	}
+	ReleaseLibraryPath(dir)
 	return ""
 }
 
@@ -0,0 +1,415 @@
+package mcservice
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+	"github.com/wailsapp/wails/v3/pkg/application"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	ContentKindResourcePack = "resource_pack"
+	ContentKindBehaviorPack = "behavior_pack"
+	ContentKindSkinPack     = "skin_pack"
+	ContentKindWorld        = "world"
+	ContentKindMod          = "mod"
+
+	ContentAdded   = "added"
+	ContentRemoved = "removed"
+	ContentChanged = "changed"
+
+	watchDebounce     = 500 * time.Millisecond
+	watchPollInterval = 30 * time.Second
+	watchNotifyFilter = windows.FILE_NOTIFY_CHANGE_FILE_NAME | windows.FILE_NOTIFY_CHANGE_DIR_NAME | windows.FILE_NOTIFY_CHANGE_SIZE | windows.FILE_NOTIFY_CHANGE_LAST_WRITE
+)
+
+type watchCollection struct {
+	kind   string
+	dir    string
+	player string
+}
+
+type contentWatcher struct {
+	versionName string
+	onChange    func(events []types.ContentChangeEvent)
+	stop        chan struct{}
+	kick        chan struct{}
+	stopEvent   windows.Handle
+	wg          sync.WaitGroup
+	watched     map[string]bool
+	state       map[string]map[string]int64
+}
+
+var (
+	watchersMu sync.Mutex
+	watchers   = map[string]*contentWatcher{}
+)
+
+func watchCollections(name string) []watchCollection {
+	roots := GetContentRoots(name)
+	var out []watchCollection
+	if strings.TrimSpace(roots.Base) == "" {
+		return out
+	}
+	shared := filepath.Join(roots.UsersRoot, "Shared", "games", "com.mojang")
+	out = append(out,
+		watchCollection{kind: ContentKindResourcePack, dir: roots.ResourcePacks},
+		watchCollection{kind: ContentKindBehaviorPack, dir: roots.BehaviorPacks},
+		watchCollection{kind: ContentKindSkinPack, dir: filepath.Join(shared, "skin_packs")},
+	)
+	for _, player := range listPlayers(roots.UsersRoot) {
+		mojang := filepath.Join(roots.UsersRoot, player, "games", "com.mojang")
+		out = append(out,
+			watchCollection{kind: ContentKindWorld, dir: filepath.Join(mojang, "minecraftWorlds"), player: player},
+			watchCollection{kind: ContentKindSkinPack, dir: filepath.Join(mojang, "skin_packs"), player: player},
+		)
+	}
+	if dir := modsDir(name); dir != "" {
+		out = append(out, watchCollection{kind: ContentKindMod, dir: dir})
+	}
+	return out
+}
+
+func modsDir(name string) string {
+	n := strings.TrimSpace(name)
+	if n == "" {
+		return ""
+	}
+	vdir, err := utils.GetVersionsDir()
+	if err != nil || strings.TrimSpace(vdir) == "" {
+		return ""
+	}
+	return filepath.Join(vdir, n, "mods")
+}
+
+func watchStamp(kind string, p string) int64 {
+	var files []string
+	switch kind {
+	case ContentKindWorld:
+		files = []string{"level.dat", "levelname.txt", "world_icon.jpeg", "world_behavior_packs.json", "world_resource_packs.json"}
+	case ContentKindMod:
+		files = []string{"manifest.json", "manifest.json.close"}
+	default:
+		files = []string{"manifest.json", "skins.json", "pack_icon.png", filepath.Join("texts", "en_US.lang")}
+	}
+	var stamp int64
+	if fi, err := os.Stat(p); err == nil {
+		stamp = fi.ModTime().UnixNano()
+	}
+	for _, f := range files {
+		if fi, err := os.Stat(filepath.Join(p, f)); err == nil {
+			stamp ^= fi.ModTime().UnixNano() + fi.Size()
+		}
+	}
+	return stamp
+}
+
+func scanCollection(c watchCollection) map[string]int64 {
+	out := map[string]int64{}
+	for _, n := range listSyncEntries(c.dir, true) {
+		out[n] = watchStamp(c.kind, filepath.Join(c.dir, n))
+	}
+	return out
+}
+
+func watchCollectionKey(c watchCollection) string {
+	return c.kind + "|" + strings.ToLower(filepath.Clean(c.dir))
+}
+
+func (w *contentWatcher) rescan(emit bool) []types.ContentChangeEvent {
+	var events []types.ContentChangeEvent
+	next := map[string]map[string]int64{}
+	for _, c := range watchCollections(w.versionName) {
+		key := watchCollectionKey(c)
+		cur := scanCollection(c)
+		next[key] = cur
+		prev, known := w.state[key]
+		if !emit || (!known && len(cur) == 0) {
+			continue
+		}
+		add := func(change string, n string) {
+			events = append(events, types.ContentChangeEvent{
+				VersionName: w.versionName,
+				Kind:        c.kind,
+				Change:      change,
+				Name:        n,
+				Path:        filepath.Join(c.dir, n),
+				Player:      c.player,
+			})
+		}
+		for n, stamp := range cur {
+			old, ok := prev[n]
+			switch {
+			case !ok:
+				add(ContentAdded, n)
+			case old != stamp:
+				add(ContentChanged, n)
+			}
+		}
+		for n := range prev {
+			if _, ok := cur[n]; !ok {
+				add(ContentRemoved, n)
+			}
+		}
+	}
+	w.state = next
+	return events
+}
+
+func contentEventName(e types.ContentChangeEvent) string {
+	names := map[string][3]string{
+		ContentKindResourcePack: {EventPackAdded, EventPackRemoved, EventPackChanged},
+		ContentKindBehaviorPack: {EventPackAdded, EventPackRemoved, EventPackChanged},
+		ContentKindSkinPack:     {EventSkinPackAdded, EventSkinPackRemoved, EventSkinPackChanged},
+		ContentKindWorld:        {EventWorldAdded, EventWorldRemoved, EventWorldChanged},
+		ContentKindMod:          {EventModAdded, EventModRemoved, EventModChanged},
+	}[e.Kind]
+	switch e.Change {
+	case ContentAdded:
+		return names[0]
+	case ContentRemoved:
+		return names[1]
+	}
+	return names[2]
+}
+
+func (w *contentWatcher) watchRoots() []string {
+	var out []string
+	if base := strings.TrimSpace(GetContentRoots(w.versionName).Base); base != "" && utils.IsDir(base) {
+		out = append(out, base)
+	}
+	if dir := modsDir(w.versionName); dir != "" && utils.IsDir(dir) {
+		out = append(out, dir)
+	}
+	return out
+}
+
+func (w *contentWatcher) notify() {
+	select {
+	case w.kick <- struct{}{}:
+	default:
+	}
+}
+
+func watchIgnored(rel string) bool {
+	segs := strings.Split(strings.ToLower(filepath.ToSlash(rel)), "/")
+	for i, seg := range segs {
+		if seg == "minecraftworlds" && i+3 < len(segs) && segs[i+2] == "db" {
+			return true
+		}
+	}
+	return false
+}
+
+func watchRelevant(buf []byte, n uint32) bool {
+	if n == 0 {
+		return true
+	}
+	var off uint32
+	for off+12 <= n {
+		info := (*windows.FileNotifyInformation)(unsafe.Pointer(&buf[off]))
+		if uintptr(off)+12+uintptr(info.FileNameLength) > uintptr(n) {
+			return true
+		}
+		name := unsafe.Slice(&info.FileName, info.FileNameLength/2)
+		if !watchIgnored(windows.UTF16ToString(name)) {
+			return true
+		}
+		if info.NextEntryOffset == 0 {
+			break
+		}
+		off += info.NextEntryOffset
+	}
+	return false
+}
+
+func watchDirectory(dir string, stopEvent windows.Handle, wg *sync.WaitGroup, notify func()) bool {
+	p, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return false
+	}
+	h, err := windows.CreateFile(p, windows.FILE_LIST_DIRECTORY,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_FLAG_BACKUP_SEMANTICS|windows.FILE_FLAG_OVERLAPPED, 0)
+	if err != nil {
+		return false
+	}
+	ev, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		_ = windows.CloseHandle(h)
+		return false
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer windows.CloseHandle(ev)
+		defer windows.CloseHandle(h)
+		buf := make([]byte, 64*1024)
+		ov := windows.Overlapped{HEvent: ev}
+		for {
+			_ = windows.ResetEvent(ev)
+			if err := windows.ReadDirectoryChanges(h, &buf[0], uint32(len(buf)), true, watchNotifyFilter, nil, &ov, 0); err != nil {
+				return
+			}
+			r, err := windows.WaitForMultipleObjects([]windows.Handle{ev, stopEvent}, false, windows.INFINITE)
+			var n uint32
+			if err != nil || r != windows.WAIT_OBJECT_0 {
+				_ = windows.CancelIoEx(h, &ov)
+				_ = windows.GetOverlappedResult(h, &ov, &n, true)
+				return
+			}
+			if err := windows.GetOverlappedResult(h, &ov, &n, false); err != nil {
+				return
+			}
+			if watchRelevant(buf, n) {
+				notify()
+			}
+		}
+	}()
+	return true
+}
+
+func (w *contentWatcher) watchDir(dir string) {
+	if watchDirectory(dir, w.stopEvent, &w.wg, w.notify) {
+		w.watched[strings.ToLower(filepath.Clean(dir))] = true
+	}
+}
+
+func (w *contentWatcher) ensureWatches() {
+	for _, dir := range w.watchRoots() {
+		if !w.watched[strings.ToLower(filepath.Clean(dir))] {
+			w.watchDir(dir)
+		}
+	}
+}
+
+func (w *contentWatcher) run() {
+	defer w.wg.Done()
+	w.state = map[string]map[string]int64{}
+	w.rescan(false)
+	w.ensureWatches()
+	poll := time.NewTicker(watchPollInterval)
+	defer poll.Stop()
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-w.kick:
+			if debounce == nil {
+				debounce = time.After(watchDebounce)
+			}
+		case <-poll.C:
+			w.ensureWatches()
+			if debounce == nil {
+				debounce = time.After(0)
+			}
+		case <-debounce:
+			debounce = nil
+			if events := w.rescan(true); len(events) > 0 && w.onChange != nil {
+				w.onChange(events)
+			}
+		}
+	}
+}
+
+func (w *contentWatcher) close() {
+	close(w.stop)
+	_ = windows.SetEvent(w.stopEvent)
+	w.wg.Wait()
+	_ = windows.CloseHandle(w.stopEvent)
+}
+
+func emitContentChanges(events []types.ContentChangeEvent) {
+	app := application.Get()
+	if app == nil {
+		return
+	}
+	for _, e := range events {
+		app.Event.Emit(contentEventName(e), e)
+	}
+	app.Event.Emit(EventContentChanged, events)
+}
+
+func StartContentWatcher(name string, invalidate func(events []types.ContentChangeEvent)) string {
+	n := strings.TrimSpace(name)
+	if n == "" {
+		return "ERR_INVALID_NAME"
+	}
+	if strings.TrimSpace(GetContentRoots(n).Base) == "" {
+		return "ERR_ACCESS_VERSIONS_DIR"
+	}
+	watchersMu.Lock()
+	defer watchersMu.Unlock()
+	if _, ok := watchers[strings.ToLower(n)]; ok {
+		return ""
+	}
+	stopEvent, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return "ERR_WATCHER_START"
+	}
+	w := &contentWatcher{
+		versionName: n,
+		stopEvent:   stopEvent,
+		watched:     map[string]bool{},
+		stop:        make(chan struct{}),
+		kick:        make(chan struct{}, 1),
+		onChange: func(events []types.ContentChangeEvent) {
+			for _, e := range events {
+				if e.Kind == ContentKindWorld {
+					InvalidateWorldIndex()
+					break
+				}
+			}
+			if invalidate != nil {
+				invalidate(events)
+			}
+			emitContentChanges(events)
+		},
+	}
+	watchers[strings.ToLower(n)] = w
+	w.wg.Add(1)
+	go w.run()
+	return ""
+}
+
+func StopContentWatcher(name string) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	watchersMu.Lock()
+	w, ok := watchers[key]
+	delete(watchers, key)
+	watchersMu.Unlock()
+	if ok {
+		w.close()
+	}
+}
+
+func StopAllContentWatchers() {
+	watchersMu.Lock()
+	all := make([]*contentWatcher, 0, len(watchers))
+	for k, w := range watchers {
+		all = append(all, w)
+		delete(watchers, k)
+	}
+	watchersMu.Unlock()
+	for _, w := range all {
+		w.close()
+	}
+}
+
+func ListContentWatchers() []string {
+	watchersMu.Lock()
+	defer watchersMu.Unlock()
+	out := make([]string, 0, len(watchers))
+	for _, w := range watchers {
+		out = append(out, w.versionName)
+	}
+	return out
+}
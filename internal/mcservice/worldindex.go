@@ -0,0 +1,217 @@
+package mcservice
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+	"github.com/liteldev/LeviLauncher/internal/versions"
+)
+
+const (
+	WorldSourceVersion = "version"
+	WorldSourceGDK     = "gdk"
+)
+
+var (
+	worldIndexMu    sync.Mutex
+	worldIndexStale bool
+)
+
+type worldIndexFile struct {
+	UpdatedAt int64                   `json:"updatedAt"`
+	Worlds    []types.WorldIndexEntry `json:"worlds"`
+}
+
+type worldRoot struct {
+	versionName string
+	source      string
+	isPreview   bool
+	usersRoot   string
+}
+
+func worldIndexPath() string {
+	return filepath.Join(utils.BaseRoot(), "index", "worlds.json")
+}
+
+func loadWorldIndex() (worldIndexFile, bool) {
+	var idx worldIndexFile
+	b, err := os.ReadFile(worldIndexPath())
+	if err != nil {
+		return idx, false
+	}
+	if err := json.Unmarshal(b, &idx); err != nil {
+		return worldIndexFile{}, false
+	}
+	return idx, true
+}
+
+func saveWorldIndex(idx worldIndexFile) error {
+	p := worldIndexPath()
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(p, b, 0644)
+}
+
+func collectWorldRoots() []worldRoot {
+	var out []worldRoot
+	for _, m := range ListVersionMetas() {
+		if !m.EnableIsolation {
+			continue
+		}
+		roots := GetContentRoots(m.Name)
+		if strings.TrimSpace(roots.UsersRoot) == "" {
+			continue
+		}
+		out = append(out, worldRoot{versionName: m.Name, source: WorldSourceVersion, isPreview: roots.IsPreview, usersRoot: roots.UsersRoot})
+	}
+	if strings.TrimSpace(utils.GetAppDataPath()) != "" {
+		for _, preview := range []bool{false, true} {
+			base := utils.GetMinecraftGDKDataPath(preview)
+			if !utils.DirExists(base) {
+				continue
+			}
+			out = append(out, worldRoot{source: WorldSourceGDK, isPreview: preview, usersRoot: filepath.Join(base, "Users")})
+		}
+	}
+	return out
+}
+
+func InvalidateWorldIndex() {
+	worldIndexMu.Lock()
+	defer worldIndexMu.Unlock()
+	worldIndexStale = true
+}
+
+func RefreshWorldIndex() []types.WorldIndexEntry {
+	worldIndexMu.Lock()
+	defer worldIndexMu.Unlock()
+	old, _ := loadWorldIndex()
+	prev := make(map[string]types.WorldIndexEntry, len(old.Worlds))
+	for _, w := range old.Worlds {
+		prev[strings.ToLower(filepath.Clean(w.Dir))] = w
+	}
+	worlds := []types.WorldIndexEntry{}
+	for _, r := range collectWorldRoots() {
+		for _, w := range listWorldDirs(r.usersRoot) {
+			fi, err := os.Stat(filepath.Join(w.Dir, "level.dat"))
+			if err != nil {
+				continue
+			}
+			mt := fi.ModTime().Unix()
+			e, ok := prev[strings.ToLower(filepath.Clean(w.Dir))]
+			if !ok || e.LevelDatModTime != mt {
+				e = indexWorld(w.Dir)
+				e.LevelDatModTime = mt
+			}
+			e.Dir = w.Dir
+			e.Folder = filepath.Base(w.Dir)
+			e.Player = w.Player
+			e.VersionName = r.versionName
+			e.Source = r.source
+			e.IsPreview = r.isPreview
+			worlds = append(worlds, e)
+		}
+	}
+	_ = saveWorldIndex(worldIndexFile{UpdatedAt: time.Now().Unix(), Worlds: worlds})
+	worldIndexStale = false
+	return worlds
+}
+
+func indexWorld(worldDir string) types.WorldIndexEntry {
+	e := types.WorldIndexEntry{Size: utils.DirSize(worldDir)}
+	if s, err := content.ReadWorldSummary(worldDir); err == nil {
+		e.LevelName = s.LevelName
+		e.Seed = s.Seed
+		e.GameMode = s.GameMode
+		e.LastPlayed = s.LastPlayed
+		e.LastOpenedWithVersion = s.LastOpenedWithVersion
+	}
+	if nm := GetWorldLevelName(worldDir); nm != "" {
+		e.LevelName = nm
+	}
+	return e
+}
+
+func SearchWorldIndex(q types.WorldSearchQuery) []types.WorldIndexEntry {
+	worldIndexMu.Lock()
+	idx, ok := loadWorldIndex()
+	stale := worldIndexStale
+	worldIndexMu.Unlock()
+	all := idx.Worlds
+	if !ok || stale {
+		all = RefreshWorldIndex()
+	}
+	text := strings.ToLower(strings.TrimSpace(q.Text))
+	targets := map[string]string{}
+	out := []types.WorldIndexEntry{}
+	for _, w := range all {
+		if v := strings.TrimSpace(q.VersionName); v != "" && !strings.EqualFold(v, w.VersionName) {
+			continue
+		}
+		if s := strings.TrimSpace(q.Source); s != "" && !strings.EqualFold(s, w.Source) {
+			continue
+		}
+		if p := strings.TrimSpace(q.Player); p != "" && !strings.EqualFold(p, w.Player) {
+			continue
+		}
+		if q.GameMode != nil && *q.GameMode != w.GameMode {
+			continue
+		}
+		if text != "" {
+			hay := strings.ToLower(strings.Join([]string{w.LevelName, w.Folder, w.Seed, w.VersionName, w.Player, w.LastOpenedWithVersion}, "\n"))
+			if !strings.Contains(hay, text) {
+				continue
+			}
+		}
+		if w.VersionName != "" {
+			tv, ok := targets[w.VersionName]
+			if !ok {
+				tv = targetGameVersion(w.VersionName)
+				targets[w.VersionName] = tv
+			}
+			w.Compatibility = worldCompatStatus(w.LastOpenedWithVersion, tv)
+		} else {
+			w.Compatibility = WorldCompatUnknown
+		}
+		out = append(out, w)
+	}
+	less := func(a, b types.WorldIndexEntry) bool {
+		return strings.ToLower(a.LevelName) < strings.ToLower(b.LevelName)
+	}
+	switch strings.ToLower(strings.TrimSpace(q.SortBy)) {
+	case "lastplayed":
+		less = func(a, b types.WorldIndexEntry) bool { return a.LastPlayed < b.LastPlayed }
+	case "size":
+		less = func(a, b types.WorldIndexEntry) bool { return a.Size < b.Size }
+	case "version":
+		less = func(a, b types.WorldIndexEntry) bool {
+			return versions.CompareGameVersion(a.LastOpenedWithVersion, b.LastOpenedWithVersion) < 0
+		}
+	case "folder":
+		less = func(a, b types.WorldIndexEntry) bool { return strings.ToLower(a.Folder) < strings.ToLower(b.Folder) }
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if q.Desc {
+			return less(out[j], out[i])
+		}
+		return less(out[i], out[j])
+	})
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[:q.Limit]
+	}
+	return out
+}
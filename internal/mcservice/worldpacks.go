@@ -0,0 +1,158 @@
+package mcservice
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/liteldev/LeviLauncher/internal/content"
+	"github.com/liteldev/LeviLauncher/internal/packages"
+	"github.com/liteldev/LeviLauncher/internal/types"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	WorldPackResource = "resource"
+	WorldPackBehavior = "behavior"
+)
+
+var worldPackFiles = map[string]string{
+	WorldPackResource: "world_resource_packs.json",
+	WorldPackBehavior: "world_behavior_packs.json",
+}
+
+func worldPackKind(p packages.Pack) string {
+	switch p.Manifest.PackType {
+	case packages.PackTypeResources:
+		return WorldPackResource
+	case packages.PackTypeBehavior:
+		return WorldPackBehavior
+	}
+	return ""
+}
+
+func installedPackByUUID(installed []packages.Pack, uuid string) (packages.Pack, bool) {
+	id := strings.ToLower(strings.TrimSpace(uuid))
+	var best packages.Pack
+	found := false
+	for _, p := range installed {
+		if worldPackKind(p) == "" || strings.ToLower(strings.TrimSpace(p.Manifest.Identity.UUID)) != id {
+			continue
+		}
+		if !found || best.Manifest.Identity.Version.Compare(p.Manifest.Identity.Version) < 0 {
+			best = p
+			found = true
+		}
+	}
+	return best, found
+}
+
+func GetWorldPacks(worldDir string, installed []packages.Pack) []types.WorldPack {
+	out := []types.WorldPack{}
+	for _, kind := range []string{WorldPackResource, WorldPackBehavior} {
+		refs, _ := content.ReadWorldPackRefs(worldDir, worldPackFiles[kind])
+		for _, r := range refs {
+			e := types.WorldPack{PackID: r.PackID, Version: r.Version, Subpack: r.Subpack, Kind: kind, Subpacks: []string{}}
+			if p, ok := installedPackByUUID(installed, r.PackID); ok && worldPackKind(p) == kind {
+				e.Installed = true
+				e.Name = p.Manifest.Name
+				e.Path = p.Path
+				e.Subpacks = subpackNames(p)
+			}
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func AttachPackToWorld(worldDir string, uuid string, subpack string, installed []packages.Pack) string {
+	if !utils.FileExists(filepath.Join(worldDir, "level.dat")) {
+		return "ERR_INVALID_WORLD_DIR"
+	}
+	p, ok := installedPackByUUID(installed, uuid)
+	if !ok {
+		return "ERR_PACK_NOT_FOUND"
+	}
+	kind := worldPackKind(p)
+	sub := strings.TrimSpace(subpack)
+	if sub != "" && (kind != WorldPackResource || !hasSubpack(p, sub)) {
+		return "ERR_SUBPACK_NOT_FOUND"
+	}
+	file := worldPackFiles[kind]
+	v := p.Manifest.Identity.Version
+	existing, err := content.ReadWorldPackRefs(worldDir, file)
+	if err != nil {
+		return "ERR_READ_FILE"
+	}
+	refs := []content.WorldPackRef{{PackID: p.Manifest.Identity.UUID, Subpack: sub, Version: []int{v.Major, v.Minor, v.Patch}}}
+	for _, r := range existing {
+		if strings.EqualFold(strings.TrimSpace(r.PackID), strings.TrimSpace(p.Manifest.Identity.UUID)) {
+			if refs[0].Raw == nil {
+				refs[0].Raw = r.Raw
+			}
+			continue
+		}
+		refs = append(refs, r)
+	}
+	if err := content.WriteWorldPackRefs(worldDir, file, refs); err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
+
+func DetachPackFromWorld(worldDir string, uuid string) string {
+	updates := map[string][]content.WorldPackRef{}
+	for _, file := range worldPackFiles {
+		refs, err := content.ReadWorldPackRefs(worldDir, file)
+		if err != nil {
+			return "ERR_READ_FILE"
+		}
+		next := make([]content.WorldPackRef, 0, len(refs))
+		for _, r := range refs {
+			if !strings.EqualFold(strings.TrimSpace(r.PackID), strings.TrimSpace(uuid)) {
+				next = append(next, r)
+			}
+		}
+		if len(next) != len(refs) {
+			updates[file] = next
+		}
+	}
+	if len(updates) == 0 {
+		return "ERR_PACK_NOT_FOUND"
+	}
+	for file, next := range updates {
+		if err := content.WriteWorldPackRefs(worldDir, file, next); err != nil {
+			return "ERR_WRITE_FILE"
+		}
+	}
+	return ""
+}
+
+func SetWorldPackSubpack(worldDir string, uuid string, subpack string, installed []packages.Pack) string {
+	file := worldPackFiles[WorldPackResource]
+	refs, err := content.ReadWorldPackRefs(worldDir, file)
+	if err != nil {
+		return "ERR_READ_FILE"
+	}
+	sub := strings.TrimSpace(subpack)
+	found := false
+	for i := range refs {
+		if !strings.EqualFold(strings.TrimSpace(refs[i].PackID), strings.TrimSpace(uuid)) {
+			continue
+		}
+		if sub != "" {
+			p, ok := installedPackByUUID(installed, uuid)
+			if !ok || !hasSubpack(p, sub) {
+				return "ERR_SUBPACK_NOT_FOUND"
+			}
+		}
+		refs[i].Subpack = sub
+		found = true
+	}
+	if !found {
+		return "ERR_PACK_NOT_FOUND"
+	}
+	if err := content.WriteWorldPackRefs(worldDir, file, refs); err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	return ""
+}
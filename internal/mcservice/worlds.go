@@ -2,6 +2,7 @@ package mcservice
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -132,23 +133,9 @@ func WriteWorldLevelDatFields(worldDir string, args map[string]any) string {
 	if strings.TrimSpace(worldDir) == "" || !utils.DirExists(worldDir) {
 		return "ERR_INVALID_WORLD_DIR"
 	}
-	var ver int32
-	var fields []types.LevelDatField
-	if v, ok := args["version"].(float64); ok {
-		ver = int32(v)
-	} else if v2, ok2 := args["version"].(int32); ok2 {
-		ver = v2
-	}
-	if arr, ok := args["fields"].([]any); ok {
-		for _, it := range arr {
-			b, _ := json.Marshal(it)
-			var f types.LevelDatField
-			_ = json.Unmarshal(b, &f)
-			fields = append(fields, f)
-		}
-	}
+	ver, fields, _ := parseLevelDatArgs(args)
 	if err := content.WriteLevelDatFields(worldDir, fields, ver); err != nil {
-		return "ERR_WRITE_FILE"
+		return levelDatWriteErrorCode(err)
 	}
 	if nm, ok := args["levelName"].(string); ok && strings.TrimSpace(nm) != "" {
 		_ = os.WriteFile(filepath.Join(worldDir, "levelname.txt"), []byte(strings.TrimSpace(nm)), 0644)
@@ -177,6 +164,14 @@ func WriteWorldLevelDatFieldsAt(worldDir string, args map[string]any) string {
 	if strings.TrimSpace(worldDir) == "" || !utils.DirExists(worldDir) {
 		return "ERR_INVALID_WORLD_DIR"
 	}
+	ver, fields, path := parseLevelDatArgs(args)
+	if err := content.WriteLevelDatFieldsAt(worldDir, path, fields, ver); err != nil {
+		return levelDatWriteErrorCode(err)
+	}
+	return ""
+}
+
+func parseLevelDatArgs(args map[string]any) (int32, []types.LevelDatField, []string) {
 	var ver int32
 	var fields []types.LevelDatField
 	var path []string
@@ -198,10 +193,28 @@ func WriteWorldLevelDatFieldsAt(worldDir string, args map[string]any) string {
 			path = append(path, fmt.Sprintf("%v", s))
 		}
 	}
-	if err := content.WriteLevelDatFieldsAt(worldDir, path, fields, ver); err != nil {
-		return "ERR_WRITE_FILE"
+	return ver, fields, path
+}
+
+func levelDatWriteErrorCode(err error) string {
+	var verr *content.LevelDatValidationError
+	if errors.As(err, &verr) {
+		return "ERR_LEVELDAT_INVALID_VALUE"
 	}
-	return ""
+	return "ERR_WRITE_FILE"
+}
+
+func GetLevelDatSchema() []types.LevelDatSchemaField {
+	return content.GetLevelDatSchema()
+}
+
+func ValidateWorldLevelDatFields(args map[string]any) []types.LevelDatFieldIssue {
+	_, fields, path := parseLevelDatArgs(args)
+	issues := content.ValidateLevelDatFields(path, fields)
+	if issues == nil {
+		return []types.LevelDatFieldIssue{}
+	}
+	return issues
 }
 
 func ImportMcworld(name string, player string, fileName string, data []byte, overwrite bool) string {
@@ -213,3 +226,53 @@ func ImportMcworld(name string, player string, fileName string, data []byte, ove
 	wp := filepath.Join(users, player, "games", "com.mojang", "minecraftWorlds")
 	return content.ImportMcworldToDir(data, fileName, wp, overwrite)
 }
+
+type worldLocation struct {
+	Player string
+	Dir    string
+}
+
+func listPlayers(usersRoot string) []string {
+	var players []string
+	u := strings.TrimSpace(usersRoot)
+	if u == "" {
+		return players
+	}
+	ents, err := os.ReadDir(u)
+	if err != nil {
+		return players
+	}
+	for _, e := range ents {
+		if !e.IsDir() {
+			continue
+		}
+		nm := strings.TrimSpace(e.Name())
+		if nm == "" || strings.EqualFold(nm, "Shared") {
+			continue
+		}
+		players = append(players, nm)
+	}
+	return players
+}
+
+func listWorldDirs(usersRoot string) []worldLocation {
+	var out []worldLocation
+	for _, player := range listPlayers(usersRoot) {
+		wp := filepath.Join(usersRoot, player, "games", "com.mojang", "minecraftWorlds")
+		ents, err := os.ReadDir(wp)
+		if err != nil {
+			continue
+		}
+		for _, e := range ents {
+			if !e.IsDir() {
+				continue
+			}
+			dir := filepath.Join(wp, e.Name())
+			if !utils.FileExists(filepath.Join(dir, "level.dat")) {
+				continue
+			}
+			out = append(out, worldLocation{Player: player, Dir: dir})
+		}
+	}
+	return out
+}
@@ -1,7 +1,6 @@
 package mods
 
 import (
-	"archive/zip"
 	"bytes"
 	"io"
 	"os"
@@ -10,6 +9,7 @@ import (
 
 	json "github.com/goccy/go-json"
 
+	"github.com/liteldev/LeviLauncher/internal/safezip"
 	"github.com/liteldev/LeviLauncher/internal/types"
 	"github.com/liteldev/LeviLauncher/internal/utils"
 )
@@ -193,6 +193,23 @@ func IsModEnabled(mcname string, modFolder string) bool {
 }
 
 func ImportZipToMods(mcname string, data []byte, overwrite bool) string {
+	return ImportZipReaderToMods(mcname, bytes.NewReader(data), int64(len(data)), overwrite)
+}
+
+func ImportZipFileToMods(mcname string, path string, overwrite bool) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return "ERR_OPEN_ZIP"
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return "ERR_OPEN_ZIP"
+	}
+	return ImportZipReaderToMods(mcname, f, fi.Size(), overwrite)
+}
+
+func ImportZipReaderToMods(mcname string, r io.ReaderAt, size int64, overwrite bool) string {
 	name := strings.TrimSpace(mcname)
 	if name == "" {
 		return "ERR_INVALID_NAME"
@@ -208,15 +225,15 @@ func ImportZipToMods(mcname string, data []byte, overwrite bool) string {
 			return "ERR_CREATE_TARGET_DIR"
 		}
 	}
-	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	zr, err := safezip.NewReader(r, size)
 	if err != nil {
-		return "ERR_OPEN_ZIP"
+		return safezip.ErrorCode(err)
 	}
 	manifestDir := ""
 	manifestName := ""
 	var manifestJson types.ModManifestJson
 	for _, f := range zr.File {
-		nameInZip := strings.TrimPrefix(f.Name, "./")
+		nameInZip := safezip.CleanName(f.Name)
 		if strings.HasSuffix(nameInZip, "/") {
 			continue
 		}
@@ -253,56 +270,26 @@ func ImportZipToMods(mcname string, data []byte, overwrite bool) string {
 			return "ERR_DUPLICATE_FOLDER"
 		}
 	}
-	for _, f := range zr.File {
-		nameInZip := strings.TrimPrefix(f.Name, "./")
-		var relInDir string
-		if manifestDir != "" {
-			if nameInZip != manifestDir && !strings.HasPrefix(nameInZip, manifestDir+"/") {
-				continue
-			}
-			relInDir = strings.TrimPrefix(strings.TrimPrefix(nameInZip, manifestDir), "/")
-		} else {
-			relInDir = nameInZip
-		}
-		target := targetRoot
-		if relInDir != "" && relInDir != "/" {
-			target = filepath.Join(targetRoot, relInDir)
-		}
-		safeTarget, _ := filepath.Abs(target)
-		safeRoot, _ := filepath.Abs(targetRoot)
-		if !strings.HasPrefix(strings.ToLower(safeTarget), strings.ToLower(safeRoot+string(os.PathSeparator))) && strings.ToLower(safeTarget) != strings.ToLower(safeRoot) {
-			continue
-		}
-		if f.FileInfo().IsDir() || strings.HasSuffix(f.Name, "/") {
-			if err := os.MkdirAll(target, 0755); err != nil {
-				return "ERR_CREATE_TARGET_DIR"
-			}
-			continue
-		}
-		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
-			return "ERR_CREATE_TARGET_DIR"
-		}
-		rc, err := f.Open()
-		if err != nil {
-			return "ERR_READ_ZIP_ENTRY"
-		}
-		out, er := os.Create(target)
-		if er != nil {
-			rc.Close()
-			return "ERR_WRITE_FILE"
-		}
-		if _, er = io.Copy(out, rc); er != nil {
-			out.Close()
-			rc.Close()
-			return "ERR_WRITE_FILE"
-		}
-		out.Close()
-		rc.Close()
+	if err := safezip.ExtractDir(zr, manifestDir, targetRoot); err != nil {
+		return safezip.ErrorCode(err)
 	}
 	return ""
 }
 
 func ImportDllToMods(mcname string, dllFileName string, data []byte, modName string, modType string, version string, overwrite bool) string {
+	return ImportDllReaderToMods(mcname, dllFileName, bytes.NewReader(data), modName, modType, version, overwrite)
+}
+
+func ImportDllFileToMods(mcname string, path string, modName string, modType string, version string, overwrite bool) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return "ERR_WRITE_FILE"
+	}
+	defer f.Close()
+	return ImportDllReaderToMods(mcname, filepath.Base(path), f, modName, modType, version, overwrite)
+}
+
+func ImportDllReaderToMods(mcname string, dllFileName string, src io.Reader, modName string, modType string, version string, overwrite bool) string {
 	name := strings.TrimSpace(mcname)
 	if name == "" {
 		return "ERR_INVALID_NAME"
@@ -347,8 +334,9 @@ func ImportDllToMods(mcname string, dllFileName string, data []byte, modName str
 	if er != nil {
 		return "ERR_WRITE_FILE"
 	}
-	if _, er = f.Write(data); er != nil {
+	if _, er = io.Copy(f, src); er != nil {
 		_ = f.Close()
+		_ = os.Remove(dllTmp)
 		return "ERR_WRITE_FILE"
 	}
 	_ = f.Sync()
@@ -0,0 +1,142 @@
+package packages
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	DependencyMissing         = "missing"
+	DependencyVersionMismatch = "version_mismatch"
+)
+
+type DependencyNode struct {
+	UUID      string           `json:"uuid"`
+	Name      string           `json:"name"`
+	Path      string           `json:"path"`
+	PackType  PackType         `json:"pack_type"`
+	Version   SemVersion       `json:"version"`
+	DependsOn []string         `json:"depends_on"`
+	Modules   []PackDependency `json:"modules"`
+}
+
+type DependencyIssue struct {
+	PackUUID   string         `json:"pack_uuid"`
+	PackName   string         `json:"pack_name"`
+	PackPath   string         `json:"pack_path"`
+	Dependency PackDependency `json:"dependency"`
+	Reason     string         `json:"reason"`
+	Installed  string         `json:"installed,omitempty"`
+}
+
+type ImportPlan struct {
+	Archives []string          `json:"archives"`
+	Imported []string          `json:"imported"`
+	Missing  []DependencyIssue `json:"missing"`
+	Error    string            `json:"error"`
+}
+
+type DependencyGraph struct {
+	Nodes  []DependencyNode  `json:"nodes"`
+	Issues []DependencyIssue `json:"issues"`
+}
+
+func packKey(uuid string) string {
+	return strings.ToLower(strings.TrimSpace(uuid))
+}
+
+func BuildDependencyGraph(packs []PackManifest) DependencyGraph {
+	g := DependencyGraph{Nodes: []DependencyNode{}, Issues: []DependencyIssue{}}
+	byUUID := make(map[string]PackManifest, len(packs))
+	for _, p := range packs {
+		k := packKey(p.Identity.UUID)
+		if k == "" {
+			continue
+		}
+		if cur, ok := byUUID[k]; !ok || cur.Identity.Version.Compare(p.Identity.Version) < 0 {
+			byUUID[k] = p
+		}
+	}
+	for _, p := range packs {
+		n := DependencyNode{
+			UUID:      packKey(p.Identity.UUID),
+			Name:      p.Name,
+			Path:      p.Location,
+			PackType:  p.PackType,
+			Version:   p.Identity.Version,
+			DependsOn: []string{},
+			Modules:   []PackDependency{},
+		}
+		for _, d := range p.Dependencies {
+			if d.UUID == "" {
+				n.Modules = append(n.Modules, d)
+				continue
+			}
+			n.DependsOn = append(n.DependsOn, d.UUID)
+			if issue, bad := checkDependency(p, d, byUUID); bad {
+				g.Issues = append(g.Issues, issue)
+			}
+		}
+		g.Nodes = append(g.Nodes, n)
+	}
+	sort.SliceStable(g.Nodes, func(i, j int) bool {
+		return strings.ToLower(g.Nodes[i].Name) < strings.ToLower(g.Nodes[j].Name)
+	})
+	return g
+}
+
+func checkDependency(p PackManifest, d PackDependency, available map[string]PackManifest) (DependencyIssue, bool) {
+	issue := DependencyIssue{
+		PackUUID:   packKey(p.Identity.UUID),
+		PackName:   p.Name,
+		PackPath:   p.Location,
+		Dependency: d,
+	}
+	dep, ok := available[d.UUID]
+	if !ok {
+		issue.Reason = DependencyMissing
+		return issue, true
+	}
+	if dep.Identity.Version.Compare(d.Version) < 0 {
+		issue.Reason = DependencyVersionMismatch
+		issue.Installed = dep.Identity.Version.String()
+		return issue, true
+	}
+	return issue, false
+}
+
+func UnresolvedDependencies(incoming []PackManifest, installed []PackManifest) []DependencyIssue {
+	all := make([]PackManifest, 0, len(incoming)+len(installed))
+	all = append(all, installed...)
+	all = append(all, incoming...)
+	avail := map[string]PackManifest{}
+	for _, p := range all {
+		k := packKey(p.Identity.UUID)
+		if cur, ok := avail[k]; k != "" && (!ok || cur.Identity.Version.Compare(p.Identity.Version) < 0) {
+			avail[k] = p
+		}
+	}
+	out := []DependencyIssue{}
+	for _, p := range incoming {
+		for _, d := range p.Dependencies {
+			if d.UUID == "" {
+				continue
+			}
+			if issue, bad := checkDependency(p, d, avail); bad {
+				out = append(out, issue)
+			}
+		}
+	}
+	return out
+}
+
+func (pm *PackManager) ResolveDependencies(versionName string) DependencyGraph {
+	pm.mu.RLock()
+	packs := pm.packs[versionName]
+	pm.mu.RUnlock()
+	manifests := make([]PackManifest, 0, len(packs))
+	for _, p := range packs {
+		manifests = append(manifests, p.Manifest)
+	}
+	return BuildDependencyGraph(manifests)
+}
@@ -0,0 +1,197 @@
+package packages
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const packIndexVersion = 2
+
+type packIndexFile struct {
+	Version   int                       `json:"version"`
+	UpdatedAt int64                     `json:"updatedAt"`
+	Entries   map[string]packIndexEntry `json:"entries"`
+}
+
+type packIndexEntry struct {
+	PackPath     string       `json:"packPath"`
+	DefaultType  PackType     `json:"defaultType"`
+	ManifestMod  int64        `json:"manifestMod"`
+	ManifestSize int64        `json:"manifestSize"`
+	TextsHash    string       `json:"textsHash"`
+	ProtectStamp string       `json:"protectStamp"`
+	HasIcon      bool         `json:"hasIcon"`
+	Language     string       `json:"language"`
+	Manifest     PackManifest `json:"manifest"`
+}
+
+type packStamp struct {
+	manifestMod  int64
+	manifestSize int64
+	textsHash    string
+	protectStamp string
+	hasIcon      bool
+}
+
+func packIndexPath() string {
+	return filepath.Join(utils.BaseRoot(), "index", "packs.json")
+}
+
+func packIndexKey(p string) string {
+	return strings.ToLower(filepath.Clean(p))
+}
+
+func (pm *PackManager) loadIndexLocked() {
+	p := packIndexPath()
+	if pm.index != nil && pm.indexPath == p {
+		return
+	}
+	pm.indexPath = p
+	pm.index = map[string]packIndexEntry{}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return
+	}
+	var f packIndexFile
+	if json.Unmarshal(b, &f) != nil || f.Version != packIndexVersion || f.Entries == nil {
+		return
+	}
+	pm.index = f.Entries
+}
+
+func (pm *PackManager) saveIndexLocked() error {
+	if err := os.MkdirAll(filepath.Dir(pm.indexPath), 0755); err != nil {
+		return err
+	}
+	b, err := json.Marshal(packIndexFile{Version: packIndexVersion, UpdatedAt: time.Now().Unix(), Entries: pm.index})
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(pm.indexPath, b, 0644)
+}
+
+func statPack(packPath string) (packStamp, bool) {
+	fi, err := os.Stat(filepath.Join(packPath, "manifest.json"))
+	if err != nil || fi.IsDir() {
+		return packStamp{}, false
+	}
+	return packStamp{
+		manifestMod:  fi.ModTime().UnixNano(),
+		manifestSize: fi.Size(),
+		textsHash:    textsFingerprint(packPath),
+		protectStamp: protectionStamp(packPath),
+		hasIcon:      utils.FileExists(filepath.Join(packPath, "pack_icon.png")),
+	}, true
+}
+
+func textsFingerprint(packPath string) string {
+	ents, err := os.ReadDir(filepath.Join(packPath, "texts"))
+	if err != nil {
+		return ""
+	}
+	names := make([]string, 0, len(ents))
+	infos := map[string]string{}
+	for _, e := range ents {
+		if e.IsDir() {
+			continue
+		}
+		lower := strings.ToLower(e.Name())
+		if !strings.HasSuffix(lower, ".lang") && lower != "languages.json" {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			continue
+		}
+		names = append(names, e.Name())
+		infos[e.Name()] = fmt.Sprintf("%d:%d", fi.Size(), fi.ModTime().UnixNano())
+	}
+	sort.Strings(names)
+	h := fnv.New64a()
+	for _, n := range names {
+		_, _ = h.Write([]byte(n + "=" + infos[n] + "\n"))
+	}
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+func protectionStamp(packPath string) string {
+	var parts []string
+	for _, name := range []string{"contents.json", "signatures.json"} {
+		if fi, err := os.Stat(filepath.Join(packPath, name)); err == nil {
+			parts = append(parts, fmt.Sprintf("%s:%d:%d", name, fi.Size(), fi.ModTime().UnixNano()))
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+func (e packIndexEntry) matches(st packStamp, defaultType PackType, language string) bool {
+	return e.DefaultType == defaultType &&
+		e.ManifestMod == st.manifestMod &&
+		e.ManifestSize == st.manifestSize &&
+		e.TextsHash == st.textsHash &&
+		e.ProtectStamp == st.protectStamp &&
+		e.HasIcon == st.hasIcon &&
+		e.Language == language
+}
+
+func (pm *PackManager) cachedPack(startPath string, defaultType PackType, language string) (Pack, bool) {
+	pm.indexMu.Lock()
+	e, ok := pm.index[packIndexKey(startPath)]
+	pm.indexMu.Unlock()
+	if !ok || e.PackPath == "" {
+		return Pack{}, false
+	}
+	if packIndexKey(e.PackPath) != packIndexKey(startPath) && utils.FileExists(filepath.Join(startPath, "manifest.json")) {
+		return Pack{}, false
+	}
+	st, ok := statPack(e.PackPath)
+	if !ok || !e.matches(st, defaultType, language) {
+		return Pack{}, false
+	}
+	m := e.Manifest
+	m.Subpacks = append([]Subpack(nil), m.Subpacks...)
+	checkSubpacks(&m)
+	return Pack{Manifest: m, Path: e.PackPath}, true
+}
+
+func (pm *PackManager) InvalidatePackIndex() {
+	pm.mu.Lock()
+	pm.packs = map[string][]Pack{}
+	pm.mu.Unlock()
+	pm.indexMu.Lock()
+	defer pm.indexMu.Unlock()
+	pm.loadIndexLocked()
+	pm.index = map[string]packIndexEntry{}
+	_ = os.Remove(pm.indexPath)
+}
+
+func (pm *PackManager) InvalidatePackPaths(versionName string, paths ...string) {
+	pm.mu.Lock()
+	delete(pm.packs, versionName)
+	pm.mu.Unlock()
+	if len(paths) == 0 {
+		return
+	}
+	pm.indexMu.Lock()
+	defer pm.indexMu.Unlock()
+	pm.loadIndexLocked()
+	changed := false
+	for _, p := range paths {
+		k := packIndexKey(p)
+		if _, ok := pm.index[k]; ok {
+			delete(pm.index, k)
+			changed = true
+		}
+	}
+	if changed {
+		_ = pm.saveIndexLocked()
+	}
+}
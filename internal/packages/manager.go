@@ -3,9 +3,11 @@ package packages
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	json "github.com/goccy/go-json"
+	"github.com/liteldev/LeviLauncher/internal/lang"
 	"github.com/liteldev/LeviLauncher/internal/utils"
 )
 
@@ -23,10 +25,30 @@ type ManifestJSON struct {
 		UUID    string `json:"uuid"`
 		Version []int  `json:"version"`
 	} `json:"modules"`
+	Subpacks []struct {
+		FolderName string `json:"folder_name"`
+		Name       string `json:"name"`
+		MemoryTier int    `json:"memory_tier"`
+	} `json:"subpacks"`
+	Dependencies []struct {
+		UUID       string `json:"uuid"`
+		ModuleName string `json:"module_name"`
+		Version    any    `json:"version"`
+	} `json:"dependencies"`
 }
 
 func (pm *PackManager) LoadPacksForVersion(versionName string, resourcePacksDir, behaviorPacksDir string, skinPacksDirs ...string) ([]Pack, error) {
 	var packs []Pack
+	language := lang.Current()
+
+	pm.indexMu.Lock()
+	pm.loadIndexLocked()
+	pm.indexMu.Unlock()
+
+	updates := map[string]packIndexEntry{}
+	seen := map[string]struct{}{}
+	hits := map[string]struct{}{}
+	var scanned []string
 
 	scanDir := func(dir string, defaultType PackType) {
 		if !utils.DirExists(dir) {
@@ -36,12 +58,21 @@ func (pm *PackManager) LoadPacksForVersion(versionName string, resourcePacksDir,
 		if err != nil {
 			return
 		}
+		scanned = append(scanned, packIndexKey(dir))
 
 		for _, e := range entries {
 			if !e.IsDir() {
 				continue
 			}
 			startPath := filepath.Join(dir, e.Name())
+			key := packIndexKey(startPath)
+			seen[key] = struct{}{}
+
+			if p, ok := pm.cachedPack(startPath, defaultType, language); ok {
+				packs = append(packs, p)
+				hits[key] = struct{}{}
+				continue
+			}
 
 			var findPackRoot func(current string, depth int) string
 			findPackRoot = func(current string, depth int) string {
@@ -74,6 +105,10 @@ func (pm *PackManager) LoadPacksForVersion(versionName string, resourcePacksDir,
 				continue
 			}
 
+			st, ok := statPack(packPath)
+			if !ok {
+				continue
+			}
 			manifestPath := filepath.Join(packPath, "manifest.json")
 
 			manifest, err := parseManifest(manifestPath, defaultType)
@@ -82,6 +117,17 @@ func (pm *PackManager) LoadPacksForVersion(versionName string, resourcePacksDir,
 					Manifest: manifest,
 					Path:     packPath,
 				})
+				updates[key] = packIndexEntry{
+					PackPath:     packPath,
+					DefaultType:  defaultType,
+					ManifestMod:  st.manifestMod,
+					ManifestSize: st.manifestSize,
+					TextsHash:    st.textsHash,
+					ProtectStamp: st.protectStamp,
+					HasIcon:      st.hasIcon,
+					Language:     language,
+					Manifest:     manifest,
+				}
 			}
 		}
 	}
@@ -92,18 +138,73 @@ func (pm *PackManager) LoadPacksForVersion(versionName string, resourcePacksDir,
 		scanDir(dir, PackTypeSkins)
 	}
 
+	pm.indexMu.Lock()
+	changed := len(updates) > 0
+	for k := range pm.index {
+		if _, ok := seen[k]; ok {
+			_, hit := hits[k]
+			_, updated := updates[k]
+			if !hit && !updated {
+				delete(pm.index, k)
+				changed = true
+			}
+			continue
+		}
+		for _, d := range scanned {
+			if strings.HasPrefix(k, d+string(filepath.Separator)) {
+				delete(pm.index, k)
+				changed = true
+				break
+			}
+		}
+	}
+	for k, e := range updates {
+		pm.index[k] = e
+	}
+	if changed {
+		_ = pm.saveIndexLocked()
+	}
+	pm.indexMu.Unlock()
+
 	pm.mu.Lock()
 	pm.packs[versionName] = packs
 	pm.mu.Unlock()
 	return packs, nil
 }
 
+func ReadPackDir(dir string, defaultType PackType) (Pack, error) {
+	manifest, err := parseManifest(filepath.Join(dir, "manifest.json"), defaultType)
+	if err != nil {
+		return Pack{}, err
+	}
+	return Pack{Manifest: manifest, Path: dir}, nil
+}
+
 func parseManifest(path string, defaultType PackType) (PackManifest, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
 		return PackManifest{}, err
 	}
 
+	pm, err := ParseManifestData(b, defaultType)
+	if err != nil {
+		return PackManifest{}, err
+	}
+	pm.Location = filepath.Dir(path)
+
+	localizeManifest(&pm, lang.ReadPackTexts(pm.Location))
+	checkSubpacks(&pm)
+	applyProtection(&pm, DetectProtection(pm.Location))
+
+	iconPath := filepath.Join(filepath.Dir(path), "pack_icon.png")
+	if utils.FileExists(iconPath) {
+		pm.PackIconLocation = iconPath
+	}
+
+	return pm, nil
+}
+
+func ParseManifestData(b []byte, defaultType PackType) (PackManifest, error) {
 	b = utils.JsonCompatBytes(b)
 
 	var raw ManifestJSON
@@ -147,91 +248,94 @@ func parseManifest(path string, defaultType PackType) (PackManifest, error) {
 		}
 	}
 
-	pm.Name = raw.Header.Name
-	pm.Description = raw.Header.Description
-	pm.Location = filepath.Dir(path)
-
-	texts := readPackTexts(pm.Location)
-	if texts != nil {
-		if val, ok := texts[pm.Name]; ok {
-			pm.Name = val
+	for _, dep := range raw.Dependencies {
+		d := PackDependency{
+			UUID:       strings.ToLower(strings.TrimSpace(dep.UUID)),
+			ModuleName: strings.TrimSpace(dep.ModuleName),
 		}
-		if val, ok := texts[pm.Description]; ok {
-			pm.Description = val
+		if d.UUID == "" && d.ModuleName == "" {
+			continue
 		}
+		d.Version, d.VersionRaw = parseDependencyVersion(dep.Version)
+		pm.Dependencies = append(pm.Dependencies, d)
 	}
 
-	iconPath := filepath.Join(filepath.Dir(path), "pack_icon.png")
-	if utils.FileExists(iconPath) {
-		pm.PackIconLocation = iconPath
+	for _, sp := range raw.Subpacks {
+		if strings.TrimSpace(sp.FolderName) == "" {
+			continue
+		}
+		pm.Subpacks = append(pm.Subpacks, Subpack{
+			FolderName: strings.TrimSpace(sp.FolderName),
+			Name:       sp.Name,
+			MemoryTier: sp.MemoryTier,
+		})
 	}
 
+	pm.Name = raw.Header.Name
+	pm.Description = raw.Header.Description
+
 	return pm, nil
 }
 
-func readPackTexts(root string) map[string]string {
-	textsDir := filepath.Join(root, "texts")
-	if !utils.DirExists(textsDir) {
-		return nil
-	}
-	langFile := filepath.Join(textsDir, "en_US.lang")
-	if !utils.FileExists(langFile) {
-		lj := filepath.Join(textsDir, "languages.json")
-		if utils.FileExists(lj) {
-			if b, err := os.ReadFile(lj); err == nil {
-				var langs []string
-				_ = json.Unmarshal(utils.JsonCompatBytes(b), &langs)
-				if len(langs) > 0 {
-					cand := filepath.Join(textsDir, strings.TrimSpace(langs[0])+".lang")
-					if utils.FileExists(cand) {
-						langFile = cand
-					}
-				}
+func parseDependencyVersion(v any) (SemVersion, string) {
+	var sv SemVersion
+	switch t := v.(type) {
+	case []any:
+		nums := make([]int, 3)
+		for i := 0; i < len(t) && i < 3; i++ {
+			if f, ok := t[i].(float64); ok {
+				nums[i] = int(f)
 			}
 		}
-	}
-	if !utils.FileExists(langFile) {
-		ents, err := os.ReadDir(textsDir)
-		if err == nil {
-			for _, e := range ents {
-				if e.IsDir() {
-					continue
-				}
-				name := strings.ToLower(strings.TrimSpace(e.Name()))
-				if strings.HasSuffix(name, ".lang") {
-					langFile = filepath.Join(textsDir, e.Name())
-					break
-				}
-			}
+		sv = SemVersion{Major: nums[0], Minor: nums[1], Patch: nums[2]}
+		return sv, sv.String()
+	case string:
+		raw := strings.TrimSpace(t)
+		core := raw
+		if i := strings.IndexAny(core, "-+"); i >= 0 {
+			core = core[:i]
 		}
+		parts := strings.Split(core, ".")
+		nums := make([]int, 3)
+		for i := 0; i < len(parts) && i < 3; i++ {
+			nums[i], _ = strconv.Atoi(strings.TrimSpace(parts[i]))
+		}
+		sv = SemVersion{Major: nums[0], Minor: nums[1], Patch: nums[2]}
+		return sv, raw
 	}
-	if !utils.FileExists(langFile) {
-		return nil
+	return sv, ""
+}
+
+func applyProtection(pm *PackManifest, protection string) {
+	pm.Protection = protection
+	pm.ReadOnly = protection != ""
+	if pm.ReadOnly {
+		pm.Identity.PackType = PackTypeCopyProtected
 	}
-	b, err := os.ReadFile(langFile)
-	if err != nil {
-		return nil
+}
+
+func checkSubpacks(pm *PackManifest) {
+	for i := range pm.Subpacks {
+		pm.Subpacks[i].Exists = utils.IsDir(filepath.Join(pm.Location, "subpacks", pm.Subpacks[i].FolderName))
 	}
-	lines := strings.Split(string(b), "\n")
-	m := make(map[string]string, 16)
-	for _, ln := range lines {
-		l := strings.TrimSpace(strings.TrimRight(ln, "\r"))
-		if l == "" || strings.HasPrefix(l, "#") {
-			continue
-		}
-		if idx := strings.IndexRune(l, '#'); idx >= 0 {
-			l = strings.TrimSpace(l[:idx])
-		}
-		if l == "" {
-			continue
-		}
-		if eq := strings.IndexRune(l, '='); eq >= 0 {
-			k := strings.TrimSpace(l[:eq])
-			v := strings.TrimSpace(l[eq+1:])
-			if k != "" {
-				m[k] = v
-			}
-		}
+}
+
+func localizeManifest(pm *PackManifest, texts lang.PackTexts) {
+	if texts.Empty() {
+		return
+	}
+	nameKey, descKey := pm.Name, pm.Description
+	for _, code := range texts.Languages {
+		pm.Translations = append(pm.Translations, PackTranslation{
+			Language:    code,
+			Name:        texts.Entries[code][nameKey],
+			Description: texts.Entries[code][descKey],
+		})
+	}
+	current := lang.Current()
+	pm.Name = texts.Localize(nameKey, current)
+	pm.Description = texts.Localize(descKey, current)
+	for i := range pm.Subpacks {
+		pm.Subpacks[i].Name = texts.Localize(pm.Subpacks[i].Name, current)
 	}
-	return m
 }
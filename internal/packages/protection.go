@@ -0,0 +1,59 @@
+package packages
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	json "github.com/goccy/go-json"
+	"github.com/liteldev/LeviLauncher/internal/utils"
+)
+
+const (
+	ProtectionEncryptedContents = "encrypted_contents"
+	ProtectionEncryptedKeys     = "encrypted_keys"
+	ProtectionSigned            = "signed"
+)
+
+var contentsMagic = []byte{0xFC, 0xB9, 0xCF, 0x9B}
+
+type contentsJSON struct {
+	Content []struct {
+		Path string `json:"path"`
+		Key  string `json:"key"`
+	} `json:"content"`
+}
+
+func DetectProtectionFS(fsys fs.FS) string {
+	if f, err := fsys.Open("contents.json"); err == nil {
+		head := make([]byte, 8)
+		n, _ := io.ReadFull(f, head)
+		_ = f.Close()
+		if n == len(head) && bytes.Equal(head[4:8], contentsMagic) {
+			return ProtectionEncryptedContents
+		}
+		if b, err := fs.ReadFile(fsys, "contents.json"); err == nil {
+			var cj contentsJSON
+			if json.Unmarshal(utils.JsonCompatBytes(b), &cj) == nil {
+				for _, c := range cj.Content {
+					if strings.TrimSpace(c.Key) != "" {
+						return ProtectionEncryptedKeys
+					}
+				}
+			}
+		}
+	}
+	if _, err := fs.Stat(fsys, "signatures.json"); err == nil {
+		return ProtectionSigned
+	}
+	return ""
+}
+
+func DetectProtection(dir string) string {
+	if strings.TrimSpace(dir) == "" || !utils.IsDir(dir) {
+		return ""
+	}
+	return DetectProtectionFS(os.DirFS(dir))
+}
@@ -29,6 +29,27 @@ func (v SemVersion) String() string {
 	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
 }
 
+func (v SemVersion) Compare(o SemVersion) int {
+	switch {
+	case v.Major != o.Major:
+		return cmpInt(v.Major, o.Major)
+	case v.Minor != o.Minor:
+		return cmpInt(v.Minor, o.Minor)
+	default:
+		return cmpInt(v.Patch, o.Patch)
+	}
+}
+
+func cmpInt(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
 type BaseGameVersion struct {
 	SemVersion
 }
@@ -44,14 +65,39 @@ type PackIdVersion struct {
 }
 
 type PackManifest struct {
-	Identity                PackIdVersion    `json:"identity"`
-	PackType                PackType         `json:"pack_type"`
-	RequiredBaseGameVersion BaseGameVersion  `json:"required_base_game_version"`
-	MinEngineVersion        MinEngineVersion `json:"min_engine_version"`
-	Name                    string           `json:"name"`
-	Description             string           `json:"description"`
-	Location                string           `json:"location"`
-	PackIconLocation        string           `json:"pack_icon_location"`
+	Identity                PackIdVersion     `json:"identity"`
+	PackType                PackType          `json:"pack_type"`
+	RequiredBaseGameVersion BaseGameVersion   `json:"required_base_game_version"`
+	MinEngineVersion        MinEngineVersion  `json:"min_engine_version"`
+	Name                    string            `json:"name"`
+	Description             string            `json:"description"`
+	Location                string            `json:"location"`
+	PackIconLocation        string            `json:"pack_icon_location"`
+	Dependencies            []PackDependency  `json:"dependencies"`
+	Subpacks                []Subpack         `json:"subpacks"`
+	Translations            []PackTranslation `json:"translations,omitempty"`
+	Protection              string            `json:"protection,omitempty"`
+	ReadOnly                bool              `json:"read_only"`
+}
+
+type PackTranslation struct {
+	Language    string `json:"language"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type Subpack struct {
+	FolderName string `json:"folder_name"`
+	Name       string `json:"name"`
+	MemoryTier int    `json:"memory_tier"`
+	Exists     bool   `json:"exists"`
+}
+
+type PackDependency struct {
+	UUID       string     `json:"uuid,omitempty"`
+	ModuleName string     `json:"module_name,omitempty"`
+	Version    SemVersion `json:"version"`
+	VersionRaw string     `json:"version_raw"`
 }
 
 type Pack struct {
@@ -62,6 +108,10 @@ type Pack struct {
 type PackManager struct {
 	mu    sync.RWMutex
 	packs map[string][]Pack
+
+	indexMu   sync.Mutex
+	indexPath string
+	index     map[string]packIndexEntry
 }
 
 func NewPackManager() *PackManager {
@@ -0,0 +1,252 @@
+package safezip
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrUnsafePath       = errors.New("archive entry escapes target directory")
+	ErrInvalidName      = errors.New("archive entry has an invalid file name")
+	ErrSymlink          = errors.New("archive contains a symlink entry")
+	ErrTooManyEntries   = errors.New("archive contains too many entries")
+	ErrTooLarge         = errors.New("archive uncompressed size exceeds limit")
+	ErrCompressionRatio = errors.New("archive compression ratio exceeds limit")
+	ErrCreateDir        = errors.New("cannot create target directory")
+	ErrReadEntry        = errors.New("cannot read archive entry")
+	ErrWriteFile        = errors.New("cannot write extracted file")
+)
+
+type Limits struct {
+	MaxEntries   int
+	MaxTotalSize int64
+	MaxRatio     float64
+	RatioFloor   int64
+}
+
+var DefaultLimits = Limits{
+	MaxEntries:   200000,
+	MaxTotalSize: 16 << 30,
+	MaxRatio:     200,
+	RatioFloor:   1 << 20,
+}
+
+type EntryError struct {
+	Name string
+	Err  error
+}
+
+func (e *EntryError) Error() string { return fmt.Sprintf("%s: %v", e.Name, e.Err) }
+
+func (e *EntryError) Unwrap() error { return e.Err }
+
+func CleanName(name string) string {
+	n := strings.TrimSpace(name)
+	n = strings.TrimPrefix(n, "./")
+	n = strings.ReplaceAll(n, "\\", "/")
+	n = strings.TrimPrefix(n, "/")
+	for strings.Contains(n, "//") {
+		n = strings.ReplaceAll(n, "//", "/")
+	}
+	return n
+}
+
+var reservedNames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+func isReservedName(seg string) bool {
+	stem := seg
+	if i := strings.IndexByte(stem, '.'); i >= 0 {
+		stem = stem[:i]
+	}
+	_, ok := reservedNames[strings.ToUpper(strings.TrimRight(stem, " "))]
+	return ok
+}
+
+func ValidateName(name string) error {
+	n := CleanName(name)
+	if strings.ContainsRune(n, 0) || filepath.VolumeName(n) != "" || strings.Contains(n, ":") {
+		return ErrUnsafePath
+	}
+	for _, seg := range strings.Split(n, "/") {
+		if seg == "" || seg == "." {
+			continue
+		}
+		if seg == ".." {
+			return ErrUnsafePath
+		}
+		if strings.HasSuffix(seg, ".") || strings.HasSuffix(seg, " ") || isReservedName(seg) {
+			return ErrInvalidName
+		}
+	}
+	return nil
+}
+
+func isSymlink(f *zip.File) bool {
+	return f.Mode()&os.ModeSymlink != 0
+}
+
+func Check(zr *zip.Reader, lim Limits) error {
+	if lim.MaxEntries > 0 && len(zr.File) > lim.MaxEntries {
+		return ErrTooManyEntries
+	}
+	var total, totalComp uint64
+	for _, f := range zr.File {
+		if err := ValidateName(f.Name); err != nil {
+			return &EntryError{Name: f.Name, Err: err}
+		}
+		if isSymlink(f) {
+			return &EntryError{Name: f.Name, Err: ErrSymlink}
+		}
+		total += f.UncompressedSize64
+		totalComp += f.CompressedSize64
+		if lim.MaxTotalSize > 0 && total > uint64(lim.MaxTotalSize) {
+			return ErrTooLarge
+		}
+		if lim.MaxRatio > 0 && f.UncompressedSize64 > uint64(lim.RatioFloor) {
+			comp := f.CompressedSize64
+			if comp == 0 || float64(f.UncompressedSize64)/float64(comp) > lim.MaxRatio {
+				return &EntryError{Name: f.Name, Err: ErrCompressionRatio}
+			}
+		}
+	}
+	if lim.MaxRatio > 0 && total > uint64(lim.RatioFloor) {
+		if totalComp == 0 || float64(total)/float64(totalComp) > lim.MaxRatio {
+			return ErrCompressionRatio
+		}
+	}
+	return nil
+}
+
+func NewReader(r io.ReaderAt, size int64) (*zip.Reader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if err := Check(zr, DefaultLimits); err != nil {
+		return nil, err
+	}
+	return zr, nil
+}
+
+func SafeJoin(root string, rel string) (string, error) {
+	if err := ValidateName(rel); err != nil {
+		return "", err
+	}
+	rel = CleanName(rel)
+	if rel == "" {
+		return root, nil
+	}
+	target := filepath.Join(root, filepath.FromSlash(path.Clean(rel)))
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", ErrUnsafePath
+	}
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return "", ErrUnsafePath
+	}
+	r, err := filepath.Rel(absRoot, absTarget)
+	if err != nil || r == ".." || strings.HasPrefix(r, ".."+string(os.PathSeparator)) {
+		return "", ErrUnsafePath
+	}
+	return target, nil
+}
+
+func ExtractEntry(f *zip.File, root string, rel string) error {
+	if isSymlink(f) {
+		return &EntryError{Name: f.Name, Err: ErrSymlink}
+	}
+	target, err := SafeJoin(root, rel)
+	if err != nil {
+		return &EntryError{Name: f.Name, Err: err}
+	}
+	if f.FileInfo().IsDir() || strings.HasSuffix(f.Name, "/") {
+		if err := os.MkdirAll(target, 0755); err != nil {
+			return &EntryError{Name: f.Name, Err: ErrCreateDir}
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return &EntryError{Name: f.Name, Err: ErrCreateDir}
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return &EntryError{Name: f.Name, Err: ErrReadEntry}
+	}
+	defer rc.Close()
+	out, err := os.Create(target)
+	if err != nil {
+		return &EntryError{Name: f.Name, Err: ErrWriteFile}
+	}
+	n, err := io.Copy(out, io.LimitReader(rc, int64(f.UncompressedSize64)+1))
+	cerr := out.Close()
+	if err != nil {
+		return &EntryError{Name: f.Name, Err: ErrReadEntry}
+	}
+	if cerr != nil {
+		return &EntryError{Name: f.Name, Err: ErrWriteFile}
+	}
+	if uint64(n) > f.UncompressedSize64 {
+		_ = os.Remove(target)
+		return &EntryError{Name: f.Name, Err: ErrTooLarge}
+	}
+	return nil
+}
+
+func ExtractDir(zr *zip.Reader, dir string, root string) error {
+	dir = strings.TrimSuffix(CleanName(dir), "/")
+	if dir == "." {
+		dir = ""
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return ErrCreateDir
+	}
+	for _, f := range zr.File {
+		name := CleanName(f.Name)
+		rel := name
+		if dir != "" {
+			if name != dir && !strings.HasPrefix(name, dir+"/") {
+				continue
+			}
+			rel = strings.TrimPrefix(strings.TrimPrefix(name, dir), "/")
+		}
+		if err := ExtractEntry(f, root, rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ErrorCode(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrUnsafePath), errors.Is(err, ErrInvalidName):
+		return "ERR_ZIP_UNSAFE_PATH"
+	case errors.Is(err, ErrSymlink):
+		return "ERR_ZIP_SYMLINK"
+	case errors.Is(err, ErrTooManyEntries):
+		return "ERR_ZIP_TOO_MANY_ENTRIES"
+	case errors.Is(err, ErrTooLarge):
+		return "ERR_ZIP_TOO_LARGE"
+	case errors.Is(err, ErrCompressionRatio):
+		return "ERR_ZIP_BOMB"
+	case errors.Is(err, ErrCreateDir):
+		return "ERR_CREATE_TARGET_DIR"
+	case errors.Is(err, ErrReadEntry):
+		return "ERR_READ_ZIP_ENTRY"
+	case errors.Is(err, ErrWriteFile):
+		return "ERR_WRITE_FILE"
+	}
+	return "ERR_OPEN_ZIP"
+}
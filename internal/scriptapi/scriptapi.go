@@ -0,0 +1,225 @@
+package scriptapi
+
+import (
+	_ "embed"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/liteldev/LeviLauncher/internal/utils"
+	"github.com/liteldev/LeviLauncher/internal/versions"
+)
+
+const (
+	StatusOK             = "ok"
+	StatusUnsupported    = "unsupported"
+	StatusUnknownModule  = "unknown_module"
+	StatusUnknownVersion = "unknown_version"
+	StatusUnknownGame    = "unknown_game"
+
+	SourceBundled  = "bundled"
+	SourceOverride = "override"
+
+	maxTableSize = 4 << 20
+)
+
+var (
+	//go:embed modules.json
+	bundledTable []byte
+
+	ErrInvalidTable = errors.New("invalid script module table")
+
+	tableMu     sync.Mutex
+	tableCache  *Table
+	tableSource string
+)
+
+type Release struct {
+	Version string `json:"version"`
+	MinGame string `json:"minGame"`
+	MaxGame string `json:"maxGame,omitempty"`
+}
+
+type Table struct {
+	Revision    int                  `json:"revision"`
+	Updated     string               `json:"updated"`
+	CoveredGame string               `json:"coveredGame"`
+	Modules     map[string][]Release `json:"modules"`
+}
+
+func overridePath() string {
+	return filepath.Join(utils.BaseRoot(), "data", "script_modules.json")
+}
+
+func parseTable(b []byte) (*Table, error) {
+	var t Table
+	if err := json.Unmarshal(utils.JsonCompatBytes(b), &t); err != nil {
+		return nil, err
+	}
+	if t.Revision <= 0 || len(t.Modules) == 0 {
+		return nil, ErrInvalidTable
+	}
+	for name, rels := range t.Modules {
+		if !strings.HasPrefix(name, "@") {
+			return nil, ErrInvalidTable
+		}
+		for _, r := range rels {
+			if strings.TrimSpace(r.Version) == "" || strings.TrimSpace(r.MinGame) == "" {
+				return nil, ErrInvalidTable
+			}
+		}
+	}
+	return &t, nil
+}
+
+func Load() (*Table, string) {
+	tableMu.Lock()
+	defer tableMu.Unlock()
+	if tableCache != nil {
+		return tableCache, tableSource
+	}
+	t, err := parseTable(bundledTable)
+	if err != nil {
+		t = &Table{Modules: map[string][]Release{}}
+	}
+	tableCache, tableSource = t, SourceBundled
+	if b, err := os.ReadFile(overridePath()); err == nil {
+		if o, err := parseTable(b); err == nil && o.Revision >= t.Revision {
+			tableCache, tableSource = o, SourceOverride
+		}
+	}
+	return tableCache, tableSource
+}
+
+func Install(b []byte) (*Table, error) {
+	t, err := parseTable(b)
+	if err != nil {
+		return nil, err
+	}
+	p := overridePath()
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return nil, err
+	}
+	if err := utils.WriteFileAtomic(p, b, 0644); err != nil {
+		return nil, err
+	}
+	tableMu.Lock()
+	tableCache, tableSource = nil, ""
+	tableMu.Unlock()
+	return t, nil
+}
+
+func Download(url string) (*Table, error) {
+	client := &http.Client{Timeout: 20 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(resp.Status)
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxTableSize))
+	if err != nil {
+		return nil, err
+	}
+	return Install(b)
+}
+
+func Reset() {
+	_ = os.Remove(overridePath())
+	tableMu.Lock()
+	tableCache, tableSource = nil, ""
+	tableMu.Unlock()
+}
+
+func IsBeta(version string) bool {
+	return strings.Contains(version, "-")
+}
+
+func gameCore(v string) string {
+	parts := strings.Split(strings.TrimSpace(v), ".")
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+	return strings.Join(parts, ".")
+}
+
+func compareModuleVersion(a string, b string) int {
+	ca, _, _ := strings.Cut(a, "-")
+	cb, _, _ := strings.Cut(b, "-")
+	pa, pb := strings.Split(ca, "."), strings.Split(cb, ".")
+	for i := 0; i < 3; i++ {
+		var x, y int
+		if i < len(pa) {
+			x, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			y, _ = strconv.Atoi(pb[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func (r Release) loadsOn(gameVersion string) bool {
+	if versions.CompareGameVersion(gameVersion, r.MinGame) < 0 {
+		return false
+	}
+	return r.MaxGame == "" || versions.CompareGameVersion(gameCore(gameVersion), r.MaxGame) <= 0
+}
+
+func (t *Table) Check(module string, version string, gameVersion string) string {
+	name := strings.ToLower(strings.TrimSpace(module))
+	ver := strings.ToLower(strings.TrimSpace(version))
+	rels, ok := t.Modules[name]
+	if !ok {
+		return StatusUnknownModule
+	}
+	if strings.TrimSpace(gameVersion) == "" {
+		return StatusUnknownGame
+	}
+	for _, r := range rels {
+		if !strings.EqualFold(r.Version, ver) {
+			continue
+		}
+		if r.loadsOn(gameVersion) {
+			return StatusOK
+		}
+		if IsBeta(ver) && t.CoveredGame != "" && versions.CompareGameVersion(gameCore(gameVersion), t.CoveredGame) > 0 {
+			return StatusUnknownGame
+		}
+		return StatusUnsupported
+	}
+	return StatusUnknownVersion
+}
+
+func (t *Table) Supported(module string, gameVersion string) []string {
+	out := []string{}
+	for _, r := range t.Modules[strings.ToLower(strings.TrimSpace(module))] {
+		if r.loadsOn(gameVersion) {
+			out = append(out, r.Version)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if c := compareModuleVersion(out[i], out[j]); c != 0 {
+			return c > 0
+		}
+		return !IsBeta(out[i]) && IsBeta(out[j])
+	})
+	return out
+}
@@ -91,6 +91,8 @@ type PackInfo struct {
 	MinEngineVersion string `json:"minEngineVersion"`
 	IconDataUrl      string `json:"iconDataUrl"`
 	Path             string `json:"path"`
+	Protection       string `json:"protection,omitempty"`
+	ReadOnly         bool   `json:"readOnly"`
 }
 
 type LevelDatField struct {
@@ -101,6 +103,32 @@ type LevelDatField struct {
 	IsBoolLike  bool     `json:"isBoolLike"`
 	InData      bool     `json:"inData"`
 	Path        []string `json:"path,omitempty"`
+	Known       bool     `json:"known"`
+}
+
+type LevelDatEnumOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+type LevelDatSchemaField struct {
+	Name     string               `json:"name"`
+	Path     []string             `json:"path,omitempty"`
+	Tag      string               `json:"tag"`
+	Kind     string               `json:"kind"`
+	Category string               `json:"category"`
+	Label    string               `json:"label"`
+	Min      *float64             `json:"min,omitempty"`
+	Max      *float64             `json:"max,omitempty"`
+	Enum     []LevelDatEnumOption `json:"enum,omitempty"`
+	ReadOnly bool                 `json:"readOnly,omitempty"`
+}
+
+type LevelDatFieldIssue struct {
+	Name  string   `json:"name"`
+	Path  []string `json:"path,omitempty"`
+	Code  string   `json:"code"`
+	Value string   `json:"value"`
 }
 
 type ExtractProgress struct {
@@ -109,3 +137,456 @@ type ExtractProgress struct {
 	Bytes int64  `json:"bytes"`
 	Ts    int64  `json:"ts"`
 }
+
+type WorldExperiment struct {
+	Key         string `json:"key"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Known       bool   `json:"known"`
+}
+
+type PackExperimentNeed struct {
+	UUID     string   `json:"uuid"`
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`
+	Required []string `json:"required"`
+	Missing  []string `json:"missing"`
+}
+
+type WorldExperimentReport struct {
+	WorldDir  string               `json:"worldDir"`
+	LevelName string               `json:"levelName"`
+	Player    string               `json:"player"`
+	Enabled   []string             `json:"enabled"`
+	Packs     []PackExperimentNeed `json:"packs"`
+}
+
+type LevelDatChange struct {
+	Path []string `json:"path"`
+	Kind string   `json:"kind"`
+	Old  string   `json:"old,omitempty"`
+	New  string   `json:"new,omitempty"`
+}
+
+type LevelDatEdit struct {
+	ID      string           `json:"id"`
+	Ts      int64            `json:"ts"`
+	Summary string           `json:"summary"`
+	Size    int64            `json:"size"`
+	Changes []LevelDatChange `json:"changes"`
+}
+
+type WorldIndexEntry struct {
+	Dir                   string `json:"dir"`
+	Folder                string `json:"folder"`
+	LevelName             string `json:"levelName"`
+	VersionName           string `json:"versionName"`
+	Source                string `json:"source"`
+	IsPreview             bool   `json:"isPreview"`
+	Player                string `json:"player"`
+	Seed                  string `json:"seed"`
+	GameMode              int32  `json:"gameMode"`
+	LastPlayed            int64  `json:"lastPlayed"`
+	Size                  int64  `json:"size"`
+	LastOpenedWithVersion string `json:"lastOpenedWithVersion"`
+	LevelDatModTime       int64  `json:"levelDatModTime"`
+	Compatibility         string `json:"compatibility"`
+}
+
+type WorldSearchQuery struct {
+	Text        string `json:"text"`
+	VersionName string `json:"versionName"`
+	Source      string `json:"source"`
+	Player      string `json:"player"`
+	GameMode    *int32 `json:"gameMode,omitempty"`
+	SortBy      string `json:"sortBy"`
+	Desc        bool   `json:"desc"`
+	Limit       int    `json:"limit"`
+}
+
+type WorldCompatibility struct {
+	WorldDir      string `json:"worldDir"`
+	LevelName     string `json:"levelName"`
+	Player        string `json:"player"`
+	WorldVersion  string `json:"worldVersion"`
+	TargetVersion string `json:"targetVersion"`
+	Status        string `json:"status"`
+}
+
+type WorldBackupResult struct {
+	WorldDir  string `json:"worldDir"`
+	LevelName string `json:"levelName"`
+	Path      string `json:"path"`
+	Error     string `json:"error"`
+}
+
+type PendingLaunch struct {
+	VersionName string               `json:"versionName"`
+	Code        string               `json:"code"`
+	Worlds      []WorldCompatibility `json:"worlds"`
+	Issues      []ScriptModuleIssue  `json:"issues"`
+}
+
+type PackCopy struct {
+	Path    string `json:"path"`
+	Folder  string `json:"folder"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	ModTime int64  `json:"modTime"`
+}
+
+type PackDuplicateGroup struct {
+	UUID   string     `json:"uuid"`
+	Name   string     `json:"name"`
+	Kind   string     `json:"kind"`
+	Newest string     `json:"newest"`
+	Copies []PackCopy `json:"copies"`
+}
+
+type PackFolderMismatch struct {
+	Path          string `json:"path"`
+	Folder        string `json:"folder"`
+	Reason        string `json:"reason"`
+	UUID          string `json:"uuid"`
+	FolderUUID    string `json:"folderUuid"`
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	FolderVersion string `json:"folderVersion"`
+}
+
+type PackAudit struct {
+	Duplicates []PackDuplicateGroup `json:"duplicates"`
+	Mismatches []PackFolderMismatch `json:"mismatches"`
+}
+
+type GlobalResourcePack struct {
+	PackID    string   `json:"pack_id"`
+	Version   []int    `json:"version"`
+	Subpack   string   `json:"subpack,omitempty"`
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Installed bool     `json:"installed"`
+	Subpacks  []string `json:"subpacks"`
+}
+
+type WorldPack struct {
+	PackID    string   `json:"pack_id"`
+	Version   []int    `json:"version"`
+	Subpack   string   `json:"subpack,omitempty"`
+	Kind      string   `json:"kind"`
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Installed bool     `json:"installed"`
+	Subpacks  []string `json:"subpacks"`
+}
+
+type PackLintIssue struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+}
+
+type PackLintReport struct {
+	Path             string          `json:"path"`
+	UUID             string          `json:"uuid"`
+	Name             string          `json:"name"`
+	MinEngineVersion string          `json:"minEngineVersion"`
+	GameVersion      string          `json:"gameVersion"`
+	Errors           int             `json:"errors"`
+	Warnings         int             `json:"warnings"`
+	Issues           []PackLintIssue `json:"issues"`
+}
+
+type PackLanguageCoverage struct {
+	Language    string   `json:"language"`
+	Declared    bool     `json:"declared"`
+	HasFile     bool     `json:"hasFile"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Total       int      `json:"total"`
+	Translated  int      `json:"translated"`
+	Percent     float64  `json:"percent"`
+	Missing     []string `json:"missing"`
+}
+
+type PackTranslationCoverage struct {
+	Path      string                 `json:"path"`
+	Current   string                 `json:"current"`
+	Keys      int                    `json:"keys"`
+	Languages []PackLanguageCoverage `json:"languages"`
+	Error     string                 `json:"error"`
+}
+
+type SkinInfo struct {
+	LocalizationName string            `json:"localizationName"`
+	Name             string            `json:"name"`
+	Names            map[string]string `json:"names"`
+	Geometry         string            `json:"geometry"`
+	Slim             bool              `json:"slim"`
+	Texture          string            `json:"texture"`
+	TextureWidth     int               `json:"textureWidth"`
+	TextureHeight    int               `json:"textureHeight"`
+	TextureDataUrl   string            `json:"textureDataUrl"`
+	Type             string            `json:"type"`
+}
+
+type SkinPackInfo struct {
+	Path             string            `json:"path"`
+	UUID             string            `json:"uuid"`
+	Version          string            `json:"version"`
+	SerializeName    string            `json:"serializeName"`
+	LocalizationName string            `json:"localizationName"`
+	Name             string            `json:"name"`
+	Names            map[string]string `json:"names"`
+	Geometries       []string          `json:"geometries"`
+	Skins            []SkinInfo        `json:"skins"`
+	Error            string            `json:"error"`
+}
+
+type SkinPackBuildRequest struct {
+	SourceDir   string                       `json:"sourceDir"`
+	OutputPath  string                       `json:"outputPath"`
+	Name        string                       `json:"name"`
+	Description string                       `json:"description"`
+	Names       map[string]string            `json:"names"`
+	SkinNames   map[string]map[string]string `json:"skinNames"`
+	UUID        string                       `json:"uuid"`
+	Version     []int                        `json:"version"`
+	SlimSuffix  string                       `json:"slimSuffix"`
+}
+
+type SkinPackBuildResult struct {
+	Path    string   `json:"path"`
+	UUID    string   `json:"uuid"`
+	Skins   int      `json:"skins"`
+	Skipped []string `json:"skipped"`
+	Error   string   `json:"error"`
+}
+
+type ScriptModuleIssue struct {
+	PackName    string   `json:"packName"`
+	PackPath    string   `json:"packPath"`
+	PackUUID    string   `json:"packUuid"`
+	Module      string   `json:"module"`
+	Version     string   `json:"version"`
+	Status      string   `json:"status"`
+	Severity    string   `json:"severity"`
+	GameVersion string   `json:"gameVersion"`
+	Supported   []string `json:"supported"`
+	Source      string   `json:"source"`
+}
+
+type ScriptModuleTableInfo struct {
+	Revision    int    `json:"revision"`
+	Updated     string `json:"updated"`
+	CoveredGame string `json:"coveredGame"`
+	Source      string `json:"source"`
+	Modules     int    `json:"modules"`
+	Error       string `json:"error"`
+}
+
+type PackScaffoldRequest struct {
+	Kind             string `json:"kind"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	OutputDir        string `json:"outputDir"`
+	MinEngineVersion []int  `json:"minEngineVersion"`
+	ScriptModule     bool   `json:"scriptModule"`
+	ScriptVersion    string `json:"scriptVersion"`
+	ScriptEntry      string `json:"scriptEntry"`
+}
+
+type ScaffoldedPack struct {
+	Kind       string `json:"kind"`
+	Path       string `json:"path"`
+	UUID       string `json:"uuid"`
+	ModuleUUID string `json:"moduleUuid"`
+}
+
+type PackScaffoldResult struct {
+	Packs []ScaffoldedPack `json:"packs"`
+	Error string           `json:"error"`
+}
+
+type PackJSONChange struct {
+	Key    string `json:"key"`
+	Change string `json:"change"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+}
+
+type PackFileChange struct {
+	Path        string           `json:"path"`
+	Change      string           `json:"change"`
+	Kind        string           `json:"kind"`
+	OldHash     string           `json:"oldHash,omitempty"`
+	NewHash     string           `json:"newHash,omitempty"`
+	OldSize     int64            `json:"oldSize"`
+	NewSize     int64            `json:"newSize"`
+	JSONChanges []PackJSONChange `json:"jsonChanges,omitempty"`
+}
+
+type PackDiff struct {
+	Old         string           `json:"old"`
+	New         string           `json:"new"`
+	UUID        string           `json:"uuid"`
+	Name        string           `json:"name"`
+	OldVersion  string           `json:"oldVersion"`
+	NewVersion  string           `json:"newVersion"`
+	VersionBump string           `json:"versionBump"`
+	UUIDChanged bool             `json:"uuidChanged"`
+	Added       int              `json:"added"`
+	Removed     int              `json:"removed"`
+	Modified    int              `json:"modified"`
+	Files       []PackFileChange `json:"files"`
+	Error       string           `json:"error"`
+}
+
+type SyncEndpoint struct {
+	VersionName string `json:"versionName"`
+	GDK         bool   `json:"gdk"`
+	Preview     bool   `json:"preview"`
+}
+
+type SyncItem struct {
+	Category      string `json:"category"`
+	RelPath       string `json:"relPath"`
+	Name          string `json:"name"`
+	Player        string `json:"player"`
+	Size          int64  `json:"size"`
+	ModTime       int64  `json:"modTime"`
+	Exists        bool   `json:"exists"`
+	TargetSize    int64  `json:"targetSize"`
+	TargetModTime int64  `json:"targetModTime"`
+	Conflict      bool   `json:"conflict"`
+	Newer         string `json:"newer"`
+}
+
+type SyncPreview struct {
+	Source string     `json:"source"`
+	Target string     `json:"target"`
+	Items  []SyncItem `json:"items"`
+	Error  string     `json:"error"`
+}
+
+type SyncRequest struct {
+	Source   SyncEndpoint `json:"source"`
+	Target   SyncEndpoint `json:"target"`
+	Items    []string     `json:"items"`
+	Strategy string       `json:"strategy"`
+}
+
+type SyncResult struct {
+	Copied  []string          `json:"copied"`
+	Skipped []string          `json:"skipped"`
+	Failed  map[string]string `json:"failed"`
+	Error   string            `json:"error"`
+}
+
+type LibraryRef struct {
+	VersionName string `json:"versionName"`
+	Path        string `json:"path"`
+	Linked      bool   `json:"linked"`
+}
+
+type LibraryEntry struct {
+	Key     string       `json:"key"`
+	UUID    string       `json:"uuid"`
+	Name    string       `json:"name"`
+	Version string       `json:"version"`
+	Kind    string       `json:"kind"`
+	Path    string       `json:"path"`
+	Size    int64        `json:"size"`
+	Refs    []LibraryRef `json:"refs"`
+}
+
+type LibraryInfo struct {
+	Root       string         `json:"root"`
+	Entries    []LibraryEntry `json:"entries"`
+	TotalSize  int64          `json:"totalSize"`
+	SavedBytes int64          `json:"savedBytes"`
+	Error      string         `json:"error"`
+}
+
+type LibraryDedupeGroup struct {
+	Key   string   `json:"key"`
+	Name  string   `json:"name"`
+	Kind  string   `json:"kind"`
+	Size  int64    `json:"size"`
+	Paths []string `json:"paths"`
+}
+
+type LibraryDedupeResult struct {
+	DryRun     bool                 `json:"dryRun"`
+	Groups     []LibraryDedupeGroup `json:"groups"`
+	Converted  []string             `json:"converted"`
+	Failed     map[string]string    `json:"failed"`
+	SavedBytes int64                `json:"savedBytes"`
+	Error      string               `json:"error"`
+}
+
+type ContentChangeEvent struct {
+	VersionName string `json:"versionName"`
+	Kind        string `json:"kind"`
+	Change      string `json:"change"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Player      string `json:"player"`
+}
+
+type DevPack struct {
+	ID          string `json:"id"`
+	VersionName string `json:"versionName"`
+	Source      string `json:"source"`
+	Kind        string `json:"kind"`
+	Mode        string `json:"mode"`
+	Folder      string `json:"folder"`
+	Target      string `json:"target"`
+	BumpVersion bool   `json:"bumpVersion"`
+	Watch       bool   `json:"watch"`
+	Revision    int    `json:"revision"`
+	LastSync    int64  `json:"lastSync"`
+	LastError   string `json:"lastError"`
+	Error       string `json:"error,omitempty"`
+}
+
+type DevPackSyncResult struct {
+	ID      string `json:"id"`
+	Copied  int    `json:"copied"`
+	Removed int    `json:"removed"`
+	Version string `json:"version"`
+	Error   string `json:"error"`
+}
+
+type PackExportRef struct {
+	VersionName string `json:"versionName"`
+	Path        string `json:"path"`
+}
+
+type PackExportRequest struct {
+	Packs  []PackExportRef `json:"packs"`
+	Format string          `json:"format"`
+	Dest   string          `json:"dest"`
+}
+
+type PackExportResult struct {
+	Files []string `json:"files"`
+	Error string   `json:"error"`
+}
+
+type ReplacedContentItem struct {
+	Original string `json:"original"`
+	Backup   string `json:"backup"`
+}
+
+type ReplacedContent struct {
+	ID       string                `json:"id"`
+	Ts       int64                 `json:"ts"`
+	Area     string                `json:"area"`
+	Replaced []ReplacedContentItem `json:"replaced"`
+	Imported []string              `json:"imported"`
+}
@@ -2,10 +2,13 @@ package utils
 
 import (
 	"archive/zip"
+	"bufio"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 )
 
 func CreateDir(path string) error {
@@ -26,6 +29,11 @@ func DirExists(path string) bool {
 	return true
 }
 
+func IsDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
 func GetDirNames(path string) []string {
 	dir, err := os.Open(path)
 	if err != nil {
@@ -204,9 +212,27 @@ func CopyDir(src, dst string) error {
 }
 
 func JsonCompatBytes(data []byte) []byte {
+	return jsonCompat(data, false)
+}
+
+func JsonCompatBytesAligned(data []byte) []byte {
+	return jsonCompat(data, true)
+}
+
+func jsonCompat(data []byte, pad bool) []byte {
 	src := data
 	n := len(src)
 	out := make([]byte, 0, n)
+	blank := func(c byte) {
+		if !pad {
+			return
+		}
+		if c == '\n' || c == '\r' {
+			out = append(out, c)
+		} else {
+			out = append(out, ' ')
+		}
+	}
 	inString := false
 	quote := byte(0)
 	esc := false
@@ -218,14 +244,20 @@ func JsonCompatBytes(data []byte) []byte {
 			if b == '\n' || b == '\r' {
 				inLineComment = false
 				out = append(out, b)
+				continue
 			}
+			blank(b)
 			continue
 		}
 		if inBlockComment {
 			if b == '*' && i+1 < n && src[i+1] == '/' {
 				inBlockComment = false
+				blank(b)
+				blank(src[i+1])
 				i++
+				continue
 			}
+			blank(b)
 			continue
 		}
 		if inString {
@@ -252,11 +284,15 @@ func JsonCompatBytes(data []byte) []byte {
 			nb := src[i+1]
 			if nb == '/' {
 				inLineComment = true
+				blank(b)
+				blank(nb)
 				i++
 				continue
 			}
 			if nb == '*' {
 				inBlockComment = true
+				blank(b)
+				blank(nb)
 				i++
 				continue
 			}
@@ -275,10 +311,121 @@ func JsonCompatBytes(data []byte) []byte {
 				break
 			}
 			out = append(out, b)
+			continue
 		next:
+			blank(b)
 			continue
 		}
 		out = append(out, b)
 	}
 	return out
 }
+
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return WriteFileAtomicFunc(path, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+func WriteFileAtomicFunc(path string, perm os.FileMode, write func(w io.Writer) error) error {
+	dir := filepath.Dir(path)
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	bw := bufio.NewWriterSize(f, 256*1024)
+	if err := write(bw); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	_ = os.Chmod(tmp, perm)
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+var zipEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func AddDirToZipDeterministic(zw *zip.Writer, srcDir string, prefix string) error {
+	var files []string
+	err := filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	names := make(map[string]string, len(files))
+	keys := make([]string, 0, len(files))
+	for _, p := range files {
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if prefix != "" {
+			name = strings.TrimSuffix(prefix, "/") + "/" + name
+		}
+		names[name] = p
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	for _, name := range keys {
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: zipEpoch}
+		header.SetMode(0644)
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(names[name])
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func IsPathWithin(root string, p string) bool {
+	r, err := filepath.Abs(strings.TrimSpace(root))
+	if err != nil || strings.TrimSpace(root) == "" {
+		return false
+	}
+	a, err := filepath.Abs(strings.TrimSpace(p))
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(r, a)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator)) && !filepath.IsAbs(rel)
+}
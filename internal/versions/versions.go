@@ -2,6 +2,7 @@ package versions
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -27,3 +28,28 @@ func ValidateFolderName(name string) string {
 	}
 	return ""
 }
+
+func CompareGameVersion(a string, b string) int {
+	pa := strings.Split(strings.TrimSpace(a), ".")
+	pb := strings.Split(strings.TrimSpace(b), ".")
+	n := len(pa)
+	if len(pb) > n {
+		n = len(pb)
+	}
+	for i := 0; i < n; i++ {
+		var x, y int
+		if i < len(pa) {
+			x, _ = strconv.Atoi(strings.TrimSpace(pa[i]))
+		}
+		if i < len(pb) {
+			y, _ = strconv.Atoi(strings.TrimSpace(pb[i]))
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -119,7 +119,9 @@ func startSingleInstanceServer(mc *Minecraft) {
 					payload := strings.TrimSpace(parts[1])
 					if cmd == "launch" && payload != "" {
 						go func(v string) {
-							_ = mc.LaunchVersionByNameForce(v)
+							if code := mc.launchFromShell(v, true); code != "" {
+								focusExistingWindow()
+							}
 						}(payload)
 					}
 				}
@@ -158,6 +160,19 @@ func init() {
 	application.RegisterEvent[string](mcservice.EventExtractError)
 	application.RegisterEvent[string](mcservice.EventExtractDone)
 	application.RegisterEvent[types.ExtractProgress](mcservice.EventExtractProgress)
+	application.RegisterEvent[[]types.ContentChangeEvent](mcservice.EventContentChanged)
+	for _, name := range []string{
+		mcservice.EventPackAdded, mcservice.EventPackRemoved, mcservice.EventPackChanged,
+		mcservice.EventWorldAdded, mcservice.EventWorldRemoved, mcservice.EventWorldChanged,
+		mcservice.EventSkinPackAdded, mcservice.EventSkinPackRemoved, mcservice.EventSkinPackChanged,
+		mcservice.EventModAdded, mcservice.EventModRemoved, mcservice.EventModChanged,
+	} {
+		application.RegisterEvent[types.ContentChangeEvent](name)
+	}
+	application.RegisterEvent[types.DevPackSyncResult](mcservice.EventDevPackSynced)
+	application.RegisterEvent[[]types.WorldCompatibility](mcservice.EventWorldDowngradeRisk)
+	application.RegisterEvent[[]types.ScriptModuleIssue](mcservice.EventScriptModuleRisk)
+	application.RegisterEvent[string](mcservice.EventLaunchPending)
 	// launch
 	application.RegisterEvent[struct{}](launch.EventMcLaunchStart)
 	application.RegisterEvent[struct{}](launch.EventMcLaunchDone)
@@ -219,9 +234,12 @@ func main() {
 	})
 	mc.startup()
 
+	autoLaunched := false
 	if strings.TrimSpace(autoLaunchVersion) != "" && initialURL == "/" {
-		_ = mc.LaunchVersionByName(autoLaunchVersion)
-		return
+		if code := mc.launchFromShell(autoLaunchVersion, false); code == "" {
+			return
+		}
+		autoLaunched = true
 	}
 
 	w := 1024
@@ -265,9 +283,12 @@ func main() {
 		URL: initialURL,
 	})
 
-	if strings.TrimSpace(autoLaunchVersion) != "" {
+	if strings.TrimSpace(autoLaunchVersion) != "" && !autoLaunched {
 		go func() {
-			_ = mc.LaunchVersionByName(autoLaunchVersion)
+			if code := mc.launchFromShell(autoLaunchVersion, false); code != "" {
+				windows.Show()
+				windows.Focus()
+			}
 		}()
 	}
 
@@ -283,6 +304,8 @@ func main() {
 		}
 	})
 	err := app.Run()
+	mc.StopAllContentWatchers()
+	mc.StopDevPackWatchers()
 
 	if err != nil {
 		log.Fatal(err.Error())
@@ -160,12 +160,212 @@ func (a *Minecraft) ListPacksForVersion(versionName string, player string) []pac
 	return packs
 }
 
+func (a *Minecraft) ClearPackIndex() {
+	a.packManager.InvalidatePackIndex()
+}
+
+func (a *Minecraft) StartContentWatcher(name string) string {
+	return mcservice.StartContentWatcher(name, func(events []types.ContentChangeEvent) {
+		var paths []string
+		for _, e := range events {
+			switch e.Kind {
+			case mcservice.ContentKindResourcePack, mcservice.ContentKindBehaviorPack, mcservice.ContentKindSkinPack:
+				paths = append(paths, e.Path)
+			}
+		}
+		if len(paths) > 0 {
+			a.packManager.InvalidatePackPaths(name, paths...)
+		}
+	})
+}
+
+func (a *Minecraft) StopContentWatcher(name string) {
+	mcservice.StopContentWatcher(name)
+}
+
+func (a *Minecraft) StopAllContentWatchers() {
+	mcservice.StopAllContentWatchers()
+}
+
+func (a *Minecraft) ListContentWatchers() []string {
+	return mcservice.ListContentWatchers()
+}
+
+func (a *Minecraft) CheckScriptModules(name string, player string) []types.ScriptModuleIssue {
+	return mcservice.CheckScriptModules(name, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) GetScriptModuleTableInfo() types.ScriptModuleTableInfo {
+	return mcservice.GetScriptModuleTableInfo()
+}
+
+func (a *Minecraft) UpdateScriptModuleTable(url string) types.ScriptModuleTableInfo {
+	return mcservice.UpdateScriptModuleTable(url)
+}
+
+func (a *Minecraft) ImportScriptModuleTable(data []byte) types.ScriptModuleTableInfo {
+	return mcservice.ImportScriptModuleTable(data)
+}
+
+func (a *Minecraft) ResetScriptModuleTable() types.ScriptModuleTableInfo {
+	return mcservice.ResetScriptModuleTable()
+}
+
+func (a *Minecraft) ScaffoldPack(name string, req types.PackScaffoldRequest) types.PackScaffoldResult {
+	return mcservice.ScaffoldPack(name, req)
+}
+
+func (a *Minecraft) ListDevPacks() []types.DevPack {
+	return mcservice.ListDevPacks()
+}
+
+func (a *Minecraft) RegisterDevPack(name string, source string, kind string, mode string, bumpVersion bool, watch bool) types.DevPack {
+	return mcservice.RegisterDevPack(name, source, kind, mode, bumpVersion, watch)
+}
+
+func (a *Minecraft) UnregisterDevPack(id string, removeTarget bool) string {
+	return mcservice.UnregisterDevPack(id, removeTarget)
+}
+
+func (a *Minecraft) SyncDevPack(id string) types.DevPackSyncResult {
+	return mcservice.SyncDevPack(id)
+}
+
+func (a *Minecraft) SetDevPackWatch(id string, watch bool) string {
+	return mcservice.SetDevPackWatch(id, watch)
+}
+
+func (a *Minecraft) StopDevPackWatchers() {
+	mcservice.StopDevPackWatchers()
+}
+
+func (a *Minecraft) GetPackDependencyGraph(versionName string, player string) packages.DependencyGraph {
+	a.ListPacksForVersion(versionName, player)
+	return a.packManager.ResolveDependencies(versionName)
+}
+
+func (a *Minecraft) DiffPacks(oldPath string, newPath string) types.PackDiff {
+	return mcservice.DiffPacks(oldPath, newPath)
+}
+
+func (a *Minecraft) PreviewPackUpdate(name string, player string, path string) []types.PackDiff {
+	return mcservice.PreviewPackUpdate(path, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) PlanPackImportPath(name string, player string, path string, selected []string) packages.ImportPlan {
+	return mcservice.PlanPackImportPath(path, selected, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) ImportPackWithDependenciesPath(name string, player string, path string, selected []string, overwrite bool) packages.ImportPlan {
+	return mcservice.ImportPackClosurePath(name, player, path, selected, overwrite, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) AuditVersionPacks(name string) types.PackAudit {
+	return mcservice.AuditVersionPacks(name)
+}
+
+func (a *Minecraft) ResolvePackDuplicates(name string, uuid string, strategy string, keepPath string) string {
+	return mcservice.ResolvePackDuplicates(name, uuid, strategy, keepPath, func(path string) error {
+		return a.removePackDir(name, path)
+	})
+}
+
+func (a *Minecraft) GetGlobalResourcePacks(name string, player string) []types.GlobalResourcePack {
+	return mcservice.GetGlobalResourcePacks(name, player, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) SetGlobalResourcePacks(name string, player string, stack []types.GlobalResourcePack) string {
+	return mcservice.SetGlobalResourcePacks(name, player, stack, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) EnableGlobalResourcePack(name string, player string, uuid string, subpack string) string {
+	return mcservice.EnableGlobalResourcePack(name, player, uuid, subpack, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) DisableGlobalResourcePack(name string, player string, uuid string) string {
+	return mcservice.DisableGlobalResourcePack(name, player, uuid)
+}
+
+func (a *Minecraft) MoveGlobalResourcePack(name string, player string, uuid string, index int) string {
+	return mcservice.MoveGlobalResourcePack(name, player, uuid, index)
+}
+
+func (a *Minecraft) SetGlobalResourcePackSubpack(name string, player string, uuid string, subpack string) string {
+	return mcservice.SetGlobalResourcePackSubpack(name, player, uuid, subpack, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) GetWorldPacks(name string, player string, worldDir string) []types.WorldPack {
+	return mcservice.GetWorldPacks(worldDir, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) AttachPackToWorld(name string, player string, worldDir string, uuid string, subpack string) string {
+	return mcservice.AttachPackToWorld(worldDir, uuid, subpack, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) DetachPackFromWorld(worldDir string, uuid string) string {
+	return mcservice.DetachPackFromWorld(worldDir, uuid)
+}
+
+func (a *Minecraft) SetWorldPackSubpack(name string, player string, worldDir string, uuid string, subpack string) string {
+	return mcservice.SetWorldPackSubpack(worldDir, uuid, subpack, a.ListPacksForVersion(name, player))
+}
+
+func (a *Minecraft) LintPackPath(name string, path string) []types.PackLintReport {
+	return mcservice.LintPackPath(name, path)
+}
+
+func (a *Minecraft) LintPackData(name string, fileName string, data []byte) []types.PackLintReport {
+	return mcservice.LintPackData(name, fileName, data)
+}
+
+func (a *Minecraft) ExportPacks(req types.PackExportRequest) types.PackExportResult {
+	return mcservice.ExportPacks(req)
+}
+
+func (a *Minecraft) ListReplacedContent(name string) []types.ReplacedContent {
+	return mcservice.ListReplacedContent(name)
+}
+
+func (a *Minecraft) RestoreReplacedContent(name string, area string, id string) string {
+	return mcservice.RestoreReplacedContent(name, area, id)
+}
+
+func (a *Minecraft) GetPackTranslationCoverage(path string) types.PackTranslationCoverage {
+	return mcservice.GetPackTranslationCoverage(path)
+}
+
+func (a *Minecraft) PreviewContentSync(source types.SyncEndpoint, target types.SyncEndpoint, categories []string) types.SyncPreview {
+	return mcservice.PreviewContentSync(source, target, categories)
+}
+
+func (a *Minecraft) SyncContent(req types.SyncRequest) types.SyncResult {
+	return mcservice.SyncContent(req)
+}
+
 func (a *Minecraft) LaunchVersionByName(name string) string {
-	return a.launchVersionInternal(name, true)
+	return a.launchVersionInternal(name, true, true)
 }
 
 func (a *Minecraft) LaunchVersionByNameForce(name string) string {
-	return a.launchVersionInternal(name, false)
+	return a.launchVersionInternal(name, false, true)
+}
+
+func (a *Minecraft) LaunchVersionByNameIgnoreRisks(name string, force bool) string {
+	return a.launchVersionInternal(name, !force, false)
+}
+
+func (a *Minecraft) launchFromShell(name string, force bool) string {
+	code := a.launchVersionInternal(name, !force, true)
+	if code == "" {
+		return ""
+	}
+	p := types.PendingLaunch{VersionName: name, Code: code}
+	if code == "ERR_WORLD_DOWNGRADE_RISK" || code == "ERR_SCRIPT_MODULE_RISK" {
+		p.Worlds = mcservice.ListDowngradeRiskWorlds(name)
+		p.Issues = mcservice.BlockingScriptModuleIssues(a.CheckScriptModules(name, ""))
+	}
+	mcservice.SetPendingLaunch(p)
+	return code
 }
 
 func (a *Minecraft) CreateDesktopShortcut(name string) string {
@@ -277,6 +477,8 @@ func (a *Minecraft) startup() {
 	os.Chdir(exeDir)
 	launch.EnsureGamingServicesInstalled(a.ctx)
 	mcservice.ReconcileRegisteredFlags()
+	content.SetReplacedPathHook(mcservice.ReleaseLibraryPath)
+	go mcservice.StartDevPackWatchers()
 }
 
 func (a *Minecraft) EnsureGameInputInteractive() { go gameinput.EnsureInteractive(a.ctx) }
@@ -440,15 +642,8 @@ func (a *Minecraft) ImportMcpack(name string, data []byte, overwrite bool) strin
 }
 
 func (a *Minecraft) ImportMcpackPath(name string, path string, overwrite bool) string {
-	if strings.TrimSpace(path) == "" {
-		return "ERR_OPEN_ZIP"
-	}
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return "ERR_OPEN_ZIP"
-	}
 	roots := a.GetContentRoots(name)
-	return content.ImportMcpackToDirs2(b, filepath.Base(path), roots.ResourcePacks, roots.BehaviorPacks, "", overwrite)
+	return content.ImportMcpackFileToDirs2(path, roots.ResourcePacks, roots.BehaviorPacks, "", overwrite)
 }
 
 func (a *Minecraft) ImportMcaddon(name string, data []byte, overwrite bool) string {
@@ -457,15 +652,8 @@ func (a *Minecraft) ImportMcaddon(name string, data []byte, overwrite bool) stri
 }
 
 func (a *Minecraft) ImportMcaddonPath(name string, path string, overwrite bool) string {
-	if strings.TrimSpace(path) == "" {
-		return "ERR_OPEN_ZIP"
-	}
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return "ERR_OPEN_ZIP"
-	}
 	roots := a.GetContentRoots(name)
-	return content.ImportMcaddonToDirs2(b, roots.ResourcePacks, roots.BehaviorPacks, "", overwrite)
+	return content.ImportMcaddonFileToDirs2(path, roots.ResourcePacks, roots.BehaviorPacks, "", overwrite)
 }
 
 func (a *Minecraft) ImportMcaddonWithPlayer(name string, player string, data []byte, overwrite bool) string {
@@ -479,20 +667,13 @@ func (a *Minecraft) ImportMcaddonWithPlayer(name string, player string, data []b
 }
 
 func (a *Minecraft) ImportMcaddonPathWithPlayer(name string, player string, path string, overwrite bool) string {
-	if strings.TrimSpace(path) == "" {
-		return "ERR_OPEN_ZIP"
-	}
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return "ERR_OPEN_ZIP"
-	}
 	roots := a.GetContentRoots(name)
 	users := strings.TrimSpace(roots.UsersRoot)
 	skinDir := ""
 	if users != "" && strings.TrimSpace(player) != "" {
 		skinDir = filepath.Join(users, player, "games", "com.mojang", "skin_packs")
 	}
-	return content.ImportMcaddonToDirs2(b, roots.ResourcePacks, roots.BehaviorPacks, skinDir, overwrite)
+	return content.ImportMcaddonFileToDirs2(path, roots.ResourcePacks, roots.BehaviorPacks, skinDir, overwrite)
 }
 
 func (a *Minecraft) ImportMcpackWithPlayer(name string, player string, fileName string, data []byte, overwrite bool) string {
@@ -506,38 +687,43 @@ func (a *Minecraft) ImportMcpackWithPlayer(name string, player string, fileName
 }
 
 func (a *Minecraft) ImportMcpackPathWithPlayer(name string, player string, path string, overwrite bool) string {
-	if strings.TrimSpace(path) == "" {
-		return "ERR_OPEN_ZIP"
-	}
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return "ERR_OPEN_ZIP"
-	}
 	roots := a.GetContentRoots(name)
 	users := strings.TrimSpace(roots.UsersRoot)
 	skinDir := ""
 	if users != "" && strings.TrimSpace(player) != "" {
 		skinDir = filepath.Join(users, player, "games", "com.mojang", "skin_packs")
 	}
-	return content.ImportMcpackToDirs2(b, filepath.Base(path), roots.ResourcePacks, roots.BehaviorPacks, skinDir, overwrite)
+	return content.ImportMcpackFileToDirs2(path, roots.ResourcePacks, roots.BehaviorPacks, skinDir, overwrite)
 }
 
 func (a *Minecraft) IsMcpackSkinPackPath(path string) bool {
-	if strings.TrimSpace(path) == "" {
-		return false
-	}
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return false
-	}
-	return content.IsMcpackSkinPack(b)
+	return content.IsMcpackSkinPackFile(path)
 }
 
 func (a *Minecraft) IsMcpackSkinPack(data []byte) bool {
 	return content.IsMcpackSkinPack(data)
 }
 
+func (a *Minecraft) GetSkinPackInfo(path string) types.SkinPackInfo {
+	return mcservice.GetSkinPackInfo(path)
+}
+
+func (a *Minecraft) BuildSkinPack(req types.SkinPackBuildRequest) types.SkinPackBuildResult {
+	return mcservice.BuildSkinPack(req)
+}
+
+func (a *Minecraft) BuildAndInstallSkinPack(name string, player string, req types.SkinPackBuildRequest) types.SkinPackBuildResult {
+	return mcservice.BuildAndInstallSkinPack(name, player, req)
+}
+
 func (a *Minecraft) ImportMcworld(name string, player string, fileName string, data []byte, overwrite bool) string {
+	if mcservice.CheckMcworldCompatibility(name, data).Status == mcservice.WorldCompatDowngrade {
+		return "ERR_WORLD_DOWNGRADE_RISK"
+	}
+	return a.ImportMcworldForce(name, player, fileName, data, overwrite)
+}
+
+func (a *Minecraft) ImportMcworldForce(name string, player string, fileName string, data []byte, overwrite bool) string {
 	roots := a.GetContentRoots(name)
 	users := strings.TrimSpace(roots.UsersRoot)
 	if users == "" || strings.TrimSpace(player) == "" {
@@ -548,20 +734,20 @@ func (a *Minecraft) ImportMcworld(name string, player string, fileName string, d
 }
 
 func (a *Minecraft) ImportMcworldPath(name string, player string, path string, overwrite bool) string {
-	if strings.TrimSpace(path) == "" {
-		return "ERR_OPEN_ZIP"
-	}
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return "ERR_OPEN_ZIP"
+	if mcservice.CheckMcworldCompatibilityPath(name, path).Status == mcservice.WorldCompatDowngrade {
+		return "ERR_WORLD_DOWNGRADE_RISK"
 	}
+	return a.ImportMcworldPathForce(name, player, path, overwrite)
+}
+
+func (a *Minecraft) ImportMcworldPathForce(name string, player string, path string, overwrite bool) string {
 	roots := a.GetContentRoots(name)
 	users := strings.TrimSpace(roots.UsersRoot)
 	if users == "" || strings.TrimSpace(player) == "" {
 		return "ERR_ACCESS_VERSIONS_DIR"
 	}
 	wp := filepath.Join(users, player, "games", "com.mojang", "minecraftWorlds")
-	return content.ImportMcworldToDir(b, filepath.Base(path), wp, overwrite)
+	return content.ImportMcworldFileToDir(path, wp, overwrite)
 }
 
 func (a *Minecraft) GetPackInfo(dir string) types.PackInfo {
@@ -611,12 +797,41 @@ func (a *Minecraft) DeletePack(name string, path string) string {
 	if !ok {
 		return "ERR_INVALID_PACKAGE"
 	}
-	if err := os.RemoveAll(absTarget); err != nil {
+	if err := a.removePackDir(name, absTarget); err != nil {
 		return "ERR_WRITE_FILE"
 	}
 	return ""
 }
 
+func (a *Minecraft) removePackDir(name string, path string) error {
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+	mcservice.ReleaseLibraryPath(path)
+	a.packManager.InvalidatePackPaths(name, path)
+	return nil
+}
+
+func (a *Minecraft) GetLibraryInfo() types.LibraryInfo {
+	return mcservice.GetLibraryInfo()
+}
+
+func (a *Minecraft) AddPackToLibrary(name string, path string) string {
+	return mcservice.AddPackToLibrary(name, path)
+}
+
+func (a *Minecraft) LinkLibraryPack(name string, key string) string {
+	return mcservice.LinkLibraryPack(name, key)
+}
+
+func (a *Minecraft) PruneLibrary() types.LibraryInfo {
+	return mcservice.PruneLibrary()
+}
+
+func (a *Minecraft) ConvertDuplicatesToShared(dryRun bool) types.LibraryDedupeResult {
+	return mcservice.ConvertDuplicatesToShared(dryRun)
+}
+
 func (a *Minecraft) DeleteWorld(name string, path string) string {
 	p := strings.TrimSpace(path)
 	if p == "" {
@@ -708,6 +923,78 @@ func (a *Minecraft) WriteWorldLevelDatFieldsAt(worldDir string, args map[string]
 	return mcservice.WriteWorldLevelDatFieldsAt(worldDir, args)
 }
 
+func (a *Minecraft) GetLevelDatSchema() []types.LevelDatSchemaField {
+	return mcservice.GetLevelDatSchema()
+}
+
+func (a *Minecraft) ValidateWorldLevelDatFields(args map[string]any) []types.LevelDatFieldIssue {
+	return mcservice.ValidateWorldLevelDatFields(args)
+}
+
+func (a *Minecraft) GetWorldExperiments(worldDir string) []types.WorldExperiment {
+	return mcservice.GetWorldExperiments(worldDir)
+}
+
+func (a *Minecraft) SetWorldExperiments(worldDir string, toggles map[string]bool) string {
+	return mcservice.SetWorldExperiments(worldDir, toggles)
+}
+
+func (a *Minecraft) GetWorldExperimentReport(worldDir string, versionName string) types.WorldExperimentReport {
+	return mcservice.GetWorldExperimentReport(worldDir, versionName)
+}
+
+func (a *Minecraft) ListWorldExperimentReports(versionName string) []types.WorldExperimentReport {
+	return mcservice.ListWorldExperimentReports(versionName)
+}
+
+func (a *Minecraft) ListWorldLevelDatHistory(worldDir string) []types.LevelDatEdit {
+	return mcservice.ListWorldLevelDatHistory(worldDir)
+}
+
+func (a *Minecraft) PreviewWorldLevelDatRevert(worldDir string, id string) []types.LevelDatChange {
+	return mcservice.PreviewWorldLevelDatRevert(worldDir, id)
+}
+
+func (a *Minecraft) RevertWorldLevelDatEdit(worldDir string, id string) string {
+	return mcservice.RevertWorldLevelDatEdit(worldDir, id)
+}
+
+func (a *Minecraft) RefreshWorldIndex() []types.WorldIndexEntry {
+	return mcservice.RefreshWorldIndex()
+}
+
+func (a *Minecraft) SearchWorldIndex(query types.WorldSearchQuery) []types.WorldIndexEntry {
+	return mcservice.SearchWorldIndex(query)
+}
+
+func (a *Minecraft) CheckWorldCompatibility(worldDir string, versionName string) types.WorldCompatibility {
+	return mcservice.CheckWorldCompatibility(worldDir, versionName)
+}
+
+func (a *Minecraft) ListWorldCompatibility(versionName string) []types.WorldCompatibility {
+	return mcservice.ListWorldCompatibility(versionName)
+}
+
+func (a *Minecraft) ListDowngradeRiskWorlds(versionName string) []types.WorldCompatibility {
+	return mcservice.ListDowngradeRiskWorlds(versionName)
+}
+
+func (a *Minecraft) BackupDowngradeRiskWorlds(versionName string) []types.WorldBackupResult {
+	return mcservice.BackupDowngradeRiskWorlds(versionName)
+}
+
+func (a *Minecraft) TakePendingLaunch() types.PendingLaunch {
+	return mcservice.TakePendingLaunch()
+}
+
+func (a *Minecraft) CheckMcworldCompatibility(versionName string, data []byte) types.WorldCompatibility {
+	return mcservice.CheckMcworldCompatibility(versionName, data)
+}
+
+func (a *Minecraft) CheckMcworldCompatibilityPath(versionName string, path string) types.WorldCompatibility {
+	return mcservice.CheckMcworldCompatibilityPath(versionName, path)
+}
+
 func (a *Minecraft) WriteTempFile(name string, data []byte) string {
 	tempDir := filepath.Join(os.TempDir(), "LeviLauncher", "TempImports")
 	_ = os.MkdirAll(tempDir, 0755)
@@ -730,22 +1017,14 @@ func (a *Minecraft) ImportModZipPath(name string, path string, overwrite bool) s
 	if strings.TrimSpace(path) == "" {
 		return "ERR_OPEN_ZIP"
 	}
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return "ERR_OPEN_ZIP"
-	}
-	return mods.ImportZipToMods(name, b, overwrite)
+	return mods.ImportZipFileToMods(name, path, overwrite)
 }
 
 func (a *Minecraft) ImportModDllPath(name string, path string, modName string, modType string, version string, overwrite bool) string {
 	if strings.TrimSpace(path) == "" {
 		return "ERR_WRITE_FILE"
 	}
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return "ERR_WRITE_FILE"
-	}
-	return mods.ImportDllToMods(name, filepath.Base(path), b, modName, modType, version, overwrite)
+	return mods.ImportDllFileToMods(name, path, modName, modType, version, overwrite)
 }
 
 func (a *Minecraft) CreateFolder(parent string, name string) string {
@@ -778,7 +1057,7 @@ func (a *Minecraft) TestMirrorLatencies(urls []string, timeoutMs int) []map[stri
 	return mcservice.TestMirrorLatencies(urls, timeoutMs)
 }
 
-func (a *Minecraft) launchVersionInternal(name string, checkRunning bool) string {
+func (a *Minecraft) launchVersionInternal(name string, checkRunning bool, checkRisks bool) string {
 	vdir, err := utils.GetVersionsDir()
 	if err != nil || strings.TrimSpace(vdir) == "" {
 		return "ERR_ACCESS_VERSIONS_DIR"
@@ -788,6 +1067,22 @@ func (a *Minecraft) launchVersionInternal(name string, checkRunning bool) string
 	if !utils.FileExists(exe) {
 		return "ERR_NOT_FOUND_EXE"
 	}
+	if checkRisks {
+		risky := mcservice.ListDowngradeRiskWorlds(name)
+		issues := mcservice.BlockingScriptModuleIssues(a.CheckScriptModules(name, ""))
+		if len(risky) > 0 {
+			application.Get().Event.Emit(mcservice.EventWorldDowngradeRisk, risky)
+		}
+		if len(issues) > 0 {
+			application.Get().Event.Emit(mcservice.EventScriptModuleRisk, issues)
+		}
+		if len(risky) > 0 {
+			return "ERR_WORLD_DOWNGRADE_RISK"
+		}
+		if len(issues) > 0 {
+			return "ERR_SCRIPT_MODULE_RISK"
+		}
+	}
 	application.Get().Event.Emit(launch.EventMcLaunchStart, struct{}{})
 	_ = vcruntime.EnsureForVersion(a.ctx, dir)
 	if isPreloader {
@@ -861,6 +1156,21 @@ func (a *Minecraft) SetDisableDiscordRPC(disable bool) string {
 	return mcservice.SetDisableDiscordRPC(disable)
 }
 
+func (a *Minecraft) GetLauncherLanguage() string {
+	return mcservice.GetLauncherLanguage()
+}
+
+func (a *Minecraft) SetLauncherLanguage(code string) string {
+	prev := lang.Current()
+	if err := mcservice.SetLauncherLanguage(code); err != "" {
+		return err
+	}
+	if lang.Current() != prev {
+		a.packManager.InvalidatePackIndex()
+	}
+	return ""
+}
+
 func (a *Minecraft) ResetBaseRoot() string { return mcservice.ResetBaseRoot() }
 
 func (a *Minecraft) CanWriteToDir(path string) bool { return mcservice.CanWriteToDir(path) }